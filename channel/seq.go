@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package channel
+
+import (
+	"context"
+	"iter"
+)
+
+// Seq returns an iterator over the values received from a channel.
+//
+// Iteration ends when the channel is closed or the context is canceled. The context is checked before
+// each receive, so no values are yielded once it is canceled, even if the channel has some buffered.
+func Seq[T any](ctx context.Context, ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ctx.Err() == nil {
+			val, state := TryRecv(ch)
+
+			switch state {
+			case StateRecv:
+			case StateClosed:
+				return
+			case StateEmpty:
+				var ok bool
+
+				select {
+				case <-ctx.Done():
+					return
+				case val, ok = <-ch:
+					if !ok {
+						return
+					}
+				}
+			}
+
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq starts a goroutine which sends the values of a sequence to the returned channel with the given buffer size.
+//
+// The channel is closed once the sequence ends or the context is canceled. The consumer should either
+// receive all values or cancel the context, otherwise the goroutine is blocked forever.
+func FromSeq[T any](ctx context.Context, seq iter.Seq[T], buf int) <-chan T {
+	ch := make(chan T, buf)
+
+	go func() {
+		defer close(ch)
+
+		for val := range seq {
+			if ctx.Err() != nil || !SendWithContext(ctx, ch, val) {
+				return
+			}
+
+			// select picks randomly among ready cases, so the send might win over cancellation:
+			// check it explicitly to stop pulling from seq
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
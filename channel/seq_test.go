@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package channel_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/channel"
+)
+
+func TestSeq(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(channel.Seq(t.Context(), ch)))
+
+	ch = make(chan int, 3)
+	ch <- 1
+	ch <- 2
+
+	for v := range channel.Seq(t.Context(), ch) {
+		assert.Equal(t, 1, v)
+
+		break
+	}
+
+	assert.Equal(t, 2, <-ch, "no values must be received after break")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	ch <- 3
+
+	// after receiving 3, iteration blocks until canceled
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	var received []int
+
+	for v := range channel.Seq(ctx, ch) {
+		received = append(received, v)
+	}
+
+	assert.Equal(t, []int{3}, received)
+
+	ch <- 4
+	assert.Empty(t, slices.Collect(channel.Seq(ctx, ch)), "canceled context must stop iteration")
+}
+
+func TestFromSeq(t *testing.T) {
+	t.Parallel()
+
+	ch := channel.FromSeq(t.Context(), slices.Values([]int{1, 2, 3}), 0)
+
+	var received []int
+
+	for v := range ch {
+		received = append(received, v)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, received)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	finished := make(chan struct{})
+	infinite := func(yield func(int) bool) {
+		defer close(finished)
+
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	ch = channel.FromSeq(ctx, infinite, 2)
+
+	assert.Equal(t, 0, <-ch)
+	assert.Equal(t, 1, <-ch)
+
+	cancel()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		require.Fail(t, "goroutine must stop on cancel")
+	}
+
+	// remaining buffered values might be received, then the channel is closed
+	for range ch { //nolint:revive
+	}
+}
+
+func TestFromSeqStop(t *testing.T) {
+	t.Parallel()
+
+	for range 50 {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		var pulled int
+
+		gate := make(chan struct{})
+		finished := make(chan struct{})
+		source := func(yield func(int) bool) {
+			defer close(finished)
+
+			for i := 0; ; i++ {
+				<-gate
+
+				pulled++
+
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		ch := channel.FromSeq(ctx, source, 10)
+
+		gate <- struct{}{}
+
+		require.Eventually(t, func() bool { return len(ch) == 1 }, time.Second, time.Millisecond)
+
+		// the buffer has room, but nothing must be sent or pulled after cancel
+		cancel()
+		close(gate)
+
+		<-finished
+
+		require.LessOrEqual(t, pulled, 2)
+	}
+}
+
+func TestSeqFromSeq(t *testing.T) {
+	t.Parallel()
+
+	values := slices.Collect(channel.Seq(t.Context(), channel.FromSeq(t.Context(), slices.Values([]int{1, 2, 3}), 1)))
+
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
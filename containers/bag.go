@@ -0,0 +1,155 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/siderolabs/gen/pair"
+)
+
+// Bag is a multiset, which counts occurrences of comparable items.
+//
+// Counts never go below zero: items are dropped from the bag once their count reaches zero.
+// The zero value is an empty bag ready to use.
+type Bag[T comparable] struct {
+	counts map[T]int
+	total  int
+}
+
+// Add adds n occurrences of the item and returns the new count. Non-positive n is ignored.
+func (b *Bag[T]) Add(item T, n int) int {
+	if n <= 0 {
+		return b.counts[item]
+	}
+
+	if b.counts == nil {
+		b.counts = map[T]int{}
+	}
+
+	b.counts[item] += n
+	b.total += n
+
+	return b.counts[item]
+}
+
+// Remove removes up to n occurrences of the item and returns the new count. Non-positive n is ignored.
+func (b *Bag[T]) Remove(item T, n int) int {
+	count, ok := b.counts[item]
+	if !ok || n <= 0 {
+		return count
+	}
+
+	if n >= count {
+		delete(b.counts, item)
+		b.total -= count
+
+		return 0
+	}
+
+	b.counts[item] = count - n
+	b.total -= n
+
+	return count - n
+}
+
+// RemoveAll removes all occurrences of the item and returns the removed count.
+func (b *Bag[T]) RemoveAll(item T) int {
+	count := b.counts[item]
+
+	delete(b.counts, item)
+	b.total -= count
+
+	return count
+}
+
+// Count returns the number of occurrences of the item.
+func (b *Bag[T]) Count(item T) int {
+	return b.counts[item]
+}
+
+// Distinct returns the number of distinct items.
+func (b *Bag[T]) Distinct() int {
+	return len(b.counts)
+}
+
+// Total returns the total number of occurrences of all items.
+func (b *Bag[T]) Total() int {
+	return b.total
+}
+
+// Clear removes all items.
+func (b *Bag[T]) Clear() {
+	b.counts = nil
+	b.total = 0
+}
+
+// All returns an iterator over distinct items and their counts. The iteration order is not specified.
+func (b *Bag[T]) All() iter.Seq2[T, int] {
+	return maps.All(b.counts)
+}
+
+// Items returns an iterator over distinct items. The iteration order is not specified.
+func (b *Bag[T]) Items() iter.Seq[T] {
+	return maps.Keys(b.counts)
+}
+
+// MostCommon returns up to n items with the highest counts, in descending order of count.
+// If n is negative, all items are returned.
+//
+// Items with equal counts are ordered by their value if T has an ordered underlying type,
+// and by their default formatting otherwise.
+func (b *Bag[T]) MostCommon(n int) []pair.Pair[T, int] {
+	items := make([]pair.Pair[T, int], 0, len(b.counts))
+
+	for item, count := range b.counts {
+		items = append(items, pair.MakePair(item, count))
+	}
+
+	slices.SortFunc(items, func(a, b pair.Pair[T, int]) int {
+		return cmp.Or(cmp.Compare(b.F2, a.F2), compareAny(a.F1, b.F1))
+	})
+
+	if n >= 0 && n < len(items) {
+		items = items[:n]
+	}
+
+	return items
+}
+
+// Sum returns a new bag where counts of each item are added together.
+func (b *Bag[T]) Sum(other *Bag[T]) *Bag[T] {
+	r := b.Clone()
+
+	for item, count := range other.counts {
+		r.Add(item, count)
+	}
+
+	return r
+}
+
+// Intersection returns a new bag with the items present in both bags, using the minimum of the two counts.
+func (b *Bag[T]) Intersection(other *Bag[T]) *Bag[T] {
+	r := &Bag[T]{}
+
+	for item, count := range b.counts {
+		r.Add(item, min(count, other.counts[item]))
+	}
+
+	return r
+}
+
+// Clone returns a copy of the bag.
+func (b *Bag[T]) Clone() *Bag[T] {
+	return &Bag[T]{counts: maps.Clone(b.counts), total: b.total}
+}
+
+// Equal reports whether both bags contain the same items with the same counts.
+func (b *Bag[T]) Equal(other *Bag[T]) bool {
+	return b.total == other.total && maps.Equal(b.counts, other.counts)
+}
@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/pair"
+)
+
+func TestBag(t *testing.T) {
+	t.Parallel()
+
+	var b containers.Bag[string]
+
+	assert.Zero(t, b.Count("a"))
+	assert.Zero(t, b.Remove("a", 1))
+
+	assert.Equal(t, 3, b.Add("a", 3))
+	assert.Equal(t, 1, b.Add("b", 1))
+	assert.Equal(t, 5, b.Add("a", 2))
+	assert.Equal(t, 2, b.Add("c", 2))
+	assert.Equal(t, 2, b.Add("c", -1))
+
+	assert.Equal(t, 3, b.Distinct())
+	assert.Equal(t, 8, b.Total())
+
+	assert.Equal(t, 3, b.Remove("a", 2))
+	assert.Equal(t, 0, b.Remove("b", 10))
+	assert.Equal(t, 2, b.Remove("c", 0))
+
+	assert.Equal(t, 2, b.Distinct())
+	assert.Equal(t, 5, b.Total())
+	assert.Equal(t, map[string]int{"a": 3, "c": 2}, maps.Collect(b.All()))
+	assert.Equal(t, []string{"a", "c"}, slices.Sorted(b.Items()))
+
+	assert.Equal(t, 2, b.RemoveAll("c"))
+	assert.Equal(t, 3, b.Total())
+
+	b.Clear()
+	assert.Zero(t, b.Total())
+	assert.Zero(t, b.Distinct())
+}
+
+func TestBagMostCommon(t *testing.T) {
+	t.Parallel()
+
+	var b containers.Bag[string]
+
+	for _, word := range []string{"x", "y", "z", "y", "z", "w", "z", "w"} {
+		b.Add(word, 1)
+	}
+
+	assert.Equal(t, []pair.Pair[string, int]{
+		pair.MakePair("z", 3),
+		pair.MakePair("w", 2),
+	}, b.MostCommon(2))
+
+	assert.Equal(t, []pair.Pair[string, int]{
+		pair.MakePair("z", 3),
+		pair.MakePair("w", 2),
+		pair.MakePair("y", 2),
+		pair.MakePair("x", 1),
+	}, b.MostCommon(-1))
+
+	assert.Len(t, b.MostCommon(10), 4)
+	assert.Empty(t, b.MostCommon(0))
+}
+
+func TestBagAlgebra(t *testing.T) {
+	t.Parallel()
+
+	var a, b containers.Bag[int]
+
+	a.Add(1, 3)
+	a.Add(2, 1)
+	b.Add(1, 1)
+	b.Add(3, 4)
+
+	sum := a.Sum(&b)
+	assert.Equal(t, map[int]int{1: 4, 2: 1, 3: 4}, maps.Collect(sum.All()))
+	assert.Equal(t, 9, sum.Total())
+
+	inter := a.Intersection(&b)
+	assert.Equal(t, map[int]int{1: 1}, maps.Collect(inter.All()))
+	assert.Equal(t, 1, inter.Total())
+
+	assert.Equal(t, 4, a.Total(), "receiver must not be modified")
+
+	assert.True(t, sum.Equal(b.Sum(&a)))
+	assert.False(t, a.Equal(&b))
+}
@@ -0,0 +1,298 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxBitSetValue is the largest value a BitSet can hold, matching the range of the list format.
+const MaxBitSetValue = math.MaxUint32
+
+// BitSet is a set of small non-negative integers stored as a bitmap.
+//
+// It uses one bit per possible value up to the largest one in the set, which makes it much more
+// compact than map[uint]struct{} for dense sets like CPU IDs, VLAN IDs or port numbers.
+// The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a new BitSet with the given bits set.
+func NewBitSet(bits ...uint) *BitSet {
+	var s BitSet
+
+	for _, i := range bits {
+		s.Set(i)
+	}
+
+	return &s
+}
+
+// ParseBitSet parses the list format used by Linux sysfs, e.g. "0-3,8,10-12".
+//
+// Whitespace around entries is ignored, and an empty string is an empty set.
+func ParseBitSet(text string) (*BitSet, error) {
+	var s BitSet
+
+	if err := s.UnmarshalText([]byte(text)); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Set adds i to the set. It panics if i is greater than MaxBitSetValue.
+func (s *BitSet) Set(i uint) {
+	s.setRange(i, i)
+}
+
+// SetRange adds all integers in [lo, hi) to the set. It panics if hi-1 is greater than MaxBitSetValue.
+func (s *BitSet) SetRange(lo, hi uint) {
+	if lo < hi {
+		s.setRange(lo, hi-1)
+	}
+}
+
+// setRange adds all integers in [lo, hi] to the set, filling whole words at once.
+func (s *BitSet) setRange(lo, hi uint) {
+	if hi > MaxBitSetValue {
+		panic(fmt.Sprintf("bit set value %d is out of range", hi))
+	}
+
+	wlo, whi := int(lo/64), int(hi/64)
+
+	if whi >= len(s.words) {
+		s.words = append(s.words, make([]uint64, whi-len(s.words)+1)...)
+	}
+
+	loMask, hiMask := ^uint64(0)<<(lo%64), ^uint64(0)>>(63-hi%64)
+
+	if wlo == whi {
+		s.words[wlo] |= loMask & hiMask
+
+		return
+	}
+
+	s.words[wlo] |= loMask
+
+	for w := wlo + 1; w < whi; w++ {
+		s.words[w] = ^uint64(0)
+	}
+
+	s.words[whi] |= hiMask
+}
+
+// Clear removes i from the set.
+func (s *BitSet) Clear(i uint) {
+	if w := int(i / 64); w < len(s.words) {
+		s.words[w] &^= 1 << (i % 64)
+	}
+}
+
+// Test reports whether i is in the set.
+func (s *BitSet) Test(i uint) bool {
+	w := int(i / 64)
+
+	return w < len(s.words) && s.words[w]&(1<<(i%64)) != 0
+}
+
+// Count returns the number of integers in the set.
+func (s *BitSet) Count() int {
+	count := 0
+
+	for _, w := range s.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
+
+// Reset removes all integers from the set.
+func (s *BitSet) Reset() {
+	s.words = nil
+}
+
+// All returns an iterator over the integers in the set in ascending order.
+func (s *BitSet) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for wi, w := range s.words {
+			for w != 0 {
+				tz := bits.TrailingZeros64(w)
+
+				if !yield(uint(wi*64 + tz)) {
+					return
+				}
+
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set.
+func (s *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), s.words...)}
+}
+
+// Equal reports whether both sets contain the same integers.
+func (s *BitSet) Equal(other *BitSet) bool {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	for i, w := range a {
+		var o uint64
+
+		if i < len(b) {
+			o = b[i]
+		}
+
+		if w != o {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union returns a new set with the integers which are in s or in other.
+func (s *BitSet) Union(other *BitSet) *BitSet {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	r := &BitSet{words: append([]uint64(nil), a...)}
+
+	for i, w := range b {
+		r.words[i] |= w
+	}
+
+	return r
+}
+
+// Intersection returns a new set with the integers which are in both s and other.
+func (s *BitSet) Intersection(other *BitSet) *BitSet {
+	r := &BitSet{words: make([]uint64, min(len(s.words), len(other.words)))}
+
+	for i := range r.words {
+		r.words[i] = s.words[i] & other.words[i]
+	}
+
+	r.trim()
+
+	return r
+}
+
+// Difference returns a new set with the integers which are in s but not in other.
+func (s *BitSet) Difference(other *BitSet) *BitSet {
+	r := s.Clone()
+
+	for i := range min(len(r.words), len(other.words)) {
+		r.words[i] &^= other.words[i]
+	}
+
+	r.trim()
+
+	return r
+}
+
+// String returns the set in the list format used by Linux sysfs, e.g. "0-3,8,10-12".
+func (s *BitSet) String() string {
+	var sb strings.Builder
+
+	start, prev, inRange := uint(0), uint(0), false
+
+	flush := func() {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(strconv.FormatUint(uint64(start), 10))
+
+		if prev > start {
+			sb.WriteByte('-')
+			sb.WriteString(strconv.FormatUint(uint64(prev), 10))
+		}
+	}
+
+	for i := range s.All() {
+		switch {
+		case !inRange:
+			start, inRange = i, true
+		case i != prev+1:
+			flush()
+
+			start = i
+		}
+
+		prev = i
+	}
+
+	if inRange {
+		flush()
+	}
+
+	return sb.String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the list format, see String.
+func (s *BitSet) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the list format, see ParseBitSet.
+func (s *BitSet) UnmarshalText(text []byte) error {
+	var r BitSet
+
+	for entry := range strings.SplitSeq(string(text), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		loStr, hiStr, isRange := strings.Cut(entry, "-")
+
+		lo, err := strconv.ParseUint(strings.TrimSpace(loStr), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid bit set entry %q: %w", entry, err)
+		}
+
+		hi := lo
+
+		if isRange {
+			if hi, err = strconv.ParseUint(strings.TrimSpace(hiStr), 10, 32); err != nil {
+				return fmt.Errorf("invalid bit set entry %q: %w", entry, err)
+			}
+
+			if hi < lo {
+				return fmt.Errorf("invalid bit set entry %q: range end is less than start", entry)
+			}
+		}
+
+		r.SetRange(uint(lo), uint(hi)+1)
+	}
+
+	*s = r
+
+	return nil
+}
+
+// trim drops trailing zero words.
+func (s *BitSet) trim() {
+	n := len(s.words)
+	for n > 0 && s.words[n-1] == 0 {
+		n--
+	}
+
+	s.words = s.words[:n]
+}
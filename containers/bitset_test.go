@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestBitSet(t *testing.T) {
+	t.Parallel()
+
+	var s containers.BitSet
+
+	assert.False(t, s.Test(100))
+	assert.Zero(t, s.Count())
+	assert.Empty(t, s.String())
+
+	s.Set(0)
+	s.Set(63)
+	s.Set(64)
+	s.Set(200)
+	s.Clear(63)
+	s.Clear(1000)
+
+	assert.True(t, s.Test(0))
+	assert.False(t, s.Test(63))
+	assert.True(t, s.Test(64))
+	assert.True(t, s.Test(200))
+	assert.False(t, s.Test(1000))
+	assert.Equal(t, 3, s.Count())
+	assert.Equal(t, []uint{0, 64, 200}, slices.Collect(s.All()))
+
+	for i := range s.All() {
+		assert.Equal(t, uint(0), i)
+
+		break
+	}
+
+	s.Reset()
+	assert.Zero(t, s.Count())
+
+	assert.Panics(t, func() { s.Set(containers.MaxBitSetValue + 1) })
+	assert.Panics(t, func() { s.SetRange(0, containers.MaxBitSetValue+2) })
+}
+
+func TestBitSetRange(t *testing.T) {
+	t.Parallel()
+
+	for _, r := range [][2]uint{{0, 0}, {5, 3}, {0, 1}, {3, 10}, {0, 64}, {63, 65}, {10, 200}, {64, 128}, {1, 640}} {
+		var expected, actual containers.BitSet
+
+		for i := r[0]; i < r[1]; i++ {
+			expected.Set(i)
+		}
+
+		// ranges are added to existing bits
+		expected.Set(700)
+		actual.Set(700)
+
+		actual.SetRange(r[0], r[1])
+
+		assert.True(t, expected.Equal(&actual), "range %v: %s != %s", r, expected.String(), actual.String())
+	}
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	a := containers.NewBitSet(1, 2, 3, 100)
+	b := containers.NewBitSet(3, 4, 300)
+
+	assert.Equal(t, []uint{1, 2, 3, 4, 100, 300}, slices.Collect(a.Union(b).All()))
+	assert.Equal(t, []uint{3}, slices.Collect(a.Intersection(b).All()))
+	assert.Equal(t, []uint{1, 2, 100}, slices.Collect(a.Difference(b).All()))
+	assert.Equal(t, []uint{4, 300}, slices.Collect(b.Difference(a).All()))
+
+	assert.True(t, a.Union(b).Equal(b.Union(a)))
+	assert.True(t, containers.NewBitSet(1).Equal(a.Difference(containers.NewBitSet(2, 3, 100))))
+	assert.True(t, a.Intersection(containers.NewBitSet(500)).Equal(&containers.BitSet{}))
+	assert.False(t, a.Equal(b))
+
+	clone := a.Clone()
+	clone.Set(7)
+	assert.False(t, a.Test(7))
+}
+
+func TestBitSetText(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		in   string
+		want []uint
+		out  string
+	}{
+		{in: "", out: ""},
+		{in: "5", want: []uint{5}, out: "5"},
+		{in: "0-3,8,10-12", want: []uint{0, 1, 2, 3, 8, 10, 11, 12}, out: "0-3,8,10-12"},
+		{in: " 8, 0-1 ,2-3\n", want: []uint{0, 1, 2, 3, 8}, out: "0-3,8"},
+		{in: "63-65,127,128", want: []uint{63, 64, 65, 127, 128}, out: "63-65,127-128"},
+	} {
+		t.Run(test.in, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := containers.ParseBitSet(test.in)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, slices.Collect(s.All()))
+			assert.Equal(t, test.out, s.String())
+		})
+	}
+
+	for _, in := range []string{"a", "1-", "3-1", "-1", "1-2-3"} {
+		_, err := containers.ParseBitSet(in)
+		assert.Error(t, err, in)
+	}
+
+	type doc struct {
+		CPUs *containers.BitSet `json:"cpus"`
+	}
+
+	data, err := json.Marshal(doc{CPUs: containers.NewBitSet(0, 1, 2, 5)})
+	require.NoError(t, err)
+	assert.Equal(t, `{"cpus":"0-2,5"}`, string(data))
+
+	var decoded doc
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []uint{0, 1, 2, 5}, slices.Collect(decoded.CPUs.All()))
+}
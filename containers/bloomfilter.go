@@ -0,0 +1,222 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// bloomSeed is shared by all BloomFilters created with NewBloomFilter, so that they can be merged.
+var bloomSeed = maphash.MakeSeed()
+
+const (
+	bloomFilterVersion = 1
+
+	// bloomFilterMaxHashes bounds the number of hash functions accepted by UnmarshalBinary.
+	// Even a false positive rate of 1e-30 needs just 100 of them.
+	bloomFilterMaxHashes = 1024
+)
+
+// BloomFilter is a probabilistic set: MayContain never reports false for added values,
+// but may report true for values which were never added.
+//
+// The filter is sized from the expected number of values and the target false positive rate.
+// Adding more values than expected keeps the filter working, but increases the false positive rate.
+type BloomFilter[T any] struct {
+	hash   func(T) uint64
+	bits   []uint64
+	m      uint64 // number of bits
+	k      uint64 // number of hash functions
+	count  uint64
+	stable bool
+}
+
+// NewBloomFilter creates a new BloomFilter for the given capacity and false positive rate.
+//
+// Values are hashed with hash/maphash using a per-process seed, so filters created in the same process
+// can be merged, but they can't be persisted: use NewBloomFilterFunc with a stable hash function for that.
+// It panics if capacity is not positive or fpRate is not in (0, 1).
+func NewBloomFilter[T comparable](capacity int, fpRate float64) *BloomFilter[T] {
+	f := newBloomFilter(capacity, fpRate, func(v T) uint64 { return maphash.Comparable(bloomSeed, v) })
+	f.stable = false
+
+	return f
+}
+
+// NewBloomFilterFunc creates a new BloomFilter for the given capacity and false positive rate, which
+// hashes values with the given function.
+//
+// If hash returns the same results across restarts (e.g. FNV of the value encoding), the filter can be
+// persisted with MarshalBinary and loaded with UnmarshalBinary into a filter with the same hash function.
+// It panics if capacity is not positive or fpRate is not in (0, 1).
+func NewBloomFilterFunc[T any](capacity int, fpRate float64, hash func(T) uint64) *BloomFilter[T] {
+	return newBloomFilter(capacity, fpRate, hash)
+}
+
+func newBloomFilter[T any](capacity int, fpRate float64, hash func(T) uint64) *BloomFilter[T] {
+	checkBloomFilterParams(capacity, fpRate)
+
+	// optimal number of bits and hash functions for the given capacity and false positive rate
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := max(uint64(math.Round(float64(m)/float64(capacity)*math.Ln2)), 1)
+
+	return &BloomFilter[T]{
+		hash:   hash,
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		stable: true,
+	}
+}
+
+func checkBloomFilterParams(capacity int, fpRate float64) {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("bloom filter capacity must be positive, got %d", capacity))
+	}
+
+	if fpRate <= 0 || fpRate >= 1 {
+		panic(fmt.Sprintf("bloom filter false positive rate must be in (0, 1), got %v", fpRate))
+	}
+}
+
+// Add adds the value to the filter.
+func (f *BloomFilter[T]) Add(v T) {
+	h1, h2 := f.hashes(v)
+
+	for i := range f.k {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+
+	f.count++
+}
+
+// MayContain reports whether the value may have been added to the filter.
+//
+// False means that the value was definitely not added.
+func (f *BloomFilter[T]) MayContain(v T) bool {
+	h1, h2 := f.hashes(v)
+
+	for i := range f.k {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Count returns the number of Add calls, including merged filters.
+func (f *BloomFilter[T]) Count() uint64 {
+	return f.count
+}
+
+// Clear removes all values from the filter.
+func (f *BloomFilter[T]) Clear() {
+	clear(f.bits)
+
+	f.count = 0
+}
+
+// Merge adds all values of the other filter to f.
+//
+// Both filters must have been created with the same parameters and hash function.
+func (f *BloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	if f.m != other.m || f.k != other.k || f.stable != other.stable {
+		return errors.New("bloom filters have different parameters")
+	}
+
+	for i, w := range other.bits {
+		f.bits[i] |= w
+	}
+
+	f.count += other.count
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// Filters created with NewBloomFilter can't be marshaled, as their hashes are only valid within the process.
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	if !f.stable {
+		return nil, errors.New("bloom filter uses per-process hash seed and can't be persisted")
+	}
+
+	data := make([]byte, 0, 1+3*8+len(f.bits)*8)
+
+	data = append(data, bloomFilterVersion)
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	data = binary.LittleEndian.AppendUint64(data, f.k)
+	data = binary.LittleEndian.AppendUint64(data, f.count)
+
+	for _, w := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The filter must be created with NewBloomFilterFunc using the same hash function as the marshaled filter;
+// its size is replaced with the marshaled one.
+func (f *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if f.hash == nil || !f.stable {
+		return errors.New("bloom filter must be created with NewBloomFilterFunc to be unmarshaled")
+	}
+
+	if len(data) < 1+3*8 {
+		return errors.New("bloom filter data is too short")
+	}
+
+	if data[0] != bloomFilterVersion {
+		return fmt.Errorf("unsupported bloom filter version %d", data[0])
+	}
+
+	m := binary.LittleEndian.Uint64(data[1:])
+	k := binary.LittleEndian.Uint64(data[9:])
+	count := binary.LittleEndian.Uint64(data[17:])
+	data = data[25:]
+
+	if len(data)%8 != 0 {
+		return errors.New("bloom filter data is corrupted")
+	}
+
+	words := uint64(len(data) / 8)
+
+	// derive the bounds from the data length to avoid overflows on corrupted m
+	if m == 0 || m > words*64 || (m+63)/64 != words || k == 0 || k > bloomFilterMaxHashes {
+		return errors.New("bloom filter data is corrupted")
+	}
+
+	bits := make([]uint64, words)
+
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+
+	f.m, f.k, f.count, f.bits = m, k, count, bits
+
+	return nil
+}
+
+// hashes derives two hashes for double hashing from the value hash.
+func (f *BloomFilter[T]) hashes(v T) (uint64, uint64) {
+	h1 := f.hash(v)
+
+	// splitmix64 finalizer
+	h2 := h1 + 0x9e3779b97f4a7c15
+	h2 = (h2 ^ (h2 >> 30)) * 0xbf58476d1ce4e5b9
+	h2 = (h2 ^ (h2 >> 27)) * 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+
+	return h1, h2 | 1
+}
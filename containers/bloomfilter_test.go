@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func fnvHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s)) //nolint:errcheck
+
+	return h.Sum64()
+}
+
+func TestBloomFilter(t *testing.T) {
+	t.Parallel()
+
+	const capacity = 10000
+
+	f := containers.NewBloomFilter[int](capacity, 0.01)
+
+	for i := range capacity {
+		f.Add(i)
+	}
+
+	assert.EqualValues(t, capacity, f.Count())
+
+	for i := range capacity {
+		require.True(t, f.MayContain(i))
+	}
+
+	falsePositives := 0
+
+	for i := capacity; i < 2*capacity; i++ {
+		if f.MayContain(i) {
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, falsePositives, capacity*2/100, "false positive rate is too high")
+
+	f.Clear()
+	assert.False(t, f.MayContain(1))
+	assert.Zero(t, f.Count())
+
+	assert.Panics(t, func() { containers.NewBloomFilter[int](0, 0.1) })
+	assert.Panics(t, func() { containers.NewBloomFilter[int](10, 1) })
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	t.Parallel()
+
+	a := containers.NewBloomFilter[string](100, 0.01)
+	b := containers.NewBloomFilter[string](100, 0.01)
+
+	a.Add("a")
+	b.Add("b")
+
+	require.NoError(t, a.Merge(b))
+	assert.True(t, a.MayContain("a"))
+	assert.True(t, a.MayContain("b"))
+	assert.EqualValues(t, 2, a.Count())
+
+	require.Error(t, a.Merge(containers.NewBloomFilter[string](1000, 0.01)))
+	require.Error(t, a.Merge(containers.NewBloomFilterFunc(100, 0.01, fnvHash)))
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	t.Parallel()
+
+	f := containers.NewBloomFilterFunc(1000, 0.001, fnvHash)
+
+	for i := range 1000 {
+		f.Add(strconv.Itoa(i))
+	}
+
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	// loading into a filter with different size replaces parameters
+	loaded := containers.NewBloomFilterFunc(10, 0.1, fnvHash)
+
+	require.NoError(t, loaded.UnmarshalBinary(data))
+	assert.EqualValues(t, 1000, loaded.Count())
+
+	for i := range 1000 {
+		require.True(t, loaded.MayContain(strconv.Itoa(i)))
+	}
+
+	require.NoError(t, f.Merge(loaded))
+
+	_, err = containers.NewBloomFilter[string](10, 0.1).MarshalBinary()
+	require.Error(t, err)
+
+	require.Error(t, containers.NewBloomFilter[string](10, 0.1).UnmarshalBinary(data))
+	require.Error(t, loaded.UnmarshalBinary(data[:10]))
+	require.Error(t, loaded.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, loaded.UnmarshalBinary(append([]byte{42}, data[1:]...)))
+
+	corrupted := func(m, k uint64, words int) []byte {
+		b := []byte{1}
+		b = binary.LittleEndian.AppendUint64(b, m)
+		b = binary.LittleEndian.AppendUint64(b, k)
+		b = binary.LittleEndian.AppendUint64(b, 0)
+
+		return append(b, make([]byte, words*8)...)
+	}
+
+	require.Error(t, loaded.UnmarshalBinary(corrupted(math.MaxUint64, 1, 0)), "m overflows the bits size")
+	require.Error(t, loaded.UnmarshalBinary(corrupted(math.MaxUint64-62, 1, 0)), "m overflows the bits size")
+	require.Error(t, loaded.UnmarshalBinary(corrupted(65, 1, 1)))
+	require.Error(t, loaded.UnmarshalBinary(corrupted(64, 0, 1)))
+	require.Error(t, loaded.UnmarshalBinary(corrupted(64, math.MaxUint64, 1)))
+	require.Error(t, loaded.UnmarshalBinary(corrupted(64, 1, 1)[:30]))
+
+	// the filter is left intact after failed attempts
+	assert.True(t, loaded.MayContain("1"))
+
+	require.NoError(t, loaded.UnmarshalBinary(corrupted(64, 1, 1)))
+	assert.False(t, loaded.MayContain("1"))
+}
@@ -0,0 +1,154 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Deque is a double-ended queue backed by a growable ring buffer.
+//
+// The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// PushBack appends the value to the back of the deque.
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+
+	d.buf[(d.head+d.n)%len(d.buf)] = val
+	d.n++
+}
+
+// PushFront prepends the value to the front of the deque.
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = val
+	d.n++
+}
+
+// PopFront removes and returns the value at the front of the deque.
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.n == 0 {
+		return *new(T), false
+	}
+
+	val := d.buf[d.head]
+	d.buf[d.head] = *new(T)
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+
+	return val, true
+}
+
+// PopBack removes and returns the value at the back of the deque.
+func (d *Deque[T]) PopBack() (T, bool) {
+	if d.n == 0 {
+		return *new(T), false
+	}
+
+	i := (d.head + d.n - 1) % len(d.buf)
+	val := d.buf[i]
+	d.buf[i] = *new(T)
+	d.n--
+
+	return val, true
+}
+
+// Front returns the value at the front of the deque.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		return *new(T), false
+	}
+
+	return d.buf[d.head], true
+}
+
+// Back returns the value at the back of the deque.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		return *new(T), false
+	}
+
+	return d.buf[(d.head+d.n-1)%len(d.buf)], true
+}
+
+// At returns the i-th value, where 0 is the front of the deque.
+//
+// It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	return d.buf[d.index(i)]
+}
+
+// Set replaces the i-th value, where 0 is the front of the deque.
+//
+// It panics if i is out of range.
+func (d *Deque[T]) Set(i int, val T) {
+	d.buf[d.index(i)] = val
+}
+
+// Clear removes all values from the deque, keeping the allocated buffer.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+
+	d.head, d.n = 0, 0
+}
+
+// All returns an iterator over index-value pairs from the front to the back of the deque.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(i, d.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs from the back to the front of the deque.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(i, d.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) index(i int) int {
+	if i < 0 || i >= d.n {
+		panic(fmt.Sprintf("deque index %d out of range [0:%d]", i, d.n))
+	}
+
+	return (d.head + i) % len(d.buf)
+}
+
+// grow makes sure there is space for one more value.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+
+	buf := make([]T, max(2*len(d.buf), 8))
+
+	// unwrap the ring so that the front is at index 0
+	copied := copy(buf, d.buf[d.head:])
+	copy(buf[copied:], d.buf[:d.head])
+
+	d.buf = buf
+	d.head = 0
+}
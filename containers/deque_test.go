@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	var d containers.Deque[int]
+
+	_, ok := d.PopFront()
+	require.False(t, ok)
+
+	_, ok = d.PopBack()
+	require.False(t, ok)
+
+	_, ok = d.Front()
+	require.False(t, ok)
+
+	_, ok = d.Back()
+	require.False(t, ok)
+
+	for i := range 10 {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+
+	assert.Equal(t, 20, d.Len())
+	assert.Equal(t, []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, collectValues(d.All()))
+
+	front, ok := d.Front()
+	require.True(t, ok)
+	assert.Equal(t, -10, front)
+
+	back, ok := d.Back()
+	require.True(t, ok)
+	assert.Equal(t, 9, back)
+
+	for range 8 {
+		d.PopFront()
+		d.PopBack()
+	}
+
+	assert.Equal(t, []int{-2, -1, 0, 1}, collectValues(d.All()))
+	assert.Equal(t, []int{1, 0, -1, -2}, collectValues(d.Backward()))
+
+	d.Set(1, 42)
+	assert.Equal(t, 42, d.At(1))
+	assert.Panics(t, func() { d.At(4) })
+	assert.Panics(t, func() { d.Set(-1, 0) })
+
+	val, ok := d.PopBack()
+	require.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	val, ok = d.PopFront()
+	require.True(t, ok)
+	assert.Equal(t, -2, val)
+
+	d.Clear()
+	assert.Zero(t, d.Len())
+
+	d.PushFront(1)
+	assert.Equal(t, []int{1}, collectValues(d.All()))
+}
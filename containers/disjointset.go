@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import "iter"
+
+// DisjointSet (or "union-find") partitions items into disjoint components.
+//
+// It uses union by rank and path compression, so operations run in nearly constant amortized time.
+// The zero value is an empty set ready to use.
+type DisjointSet[T comparable] struct {
+	index      map[T]int
+	items      []T
+	parent     []int
+	rank       []int
+	components int
+}
+
+// Add adds the item as a new single-item component, unless it is already present.
+// It reports whether the item was added.
+func (s *DisjointSet[T]) Add(item T) bool {
+	if _, ok := s.index[item]; ok {
+		return false
+	}
+
+	s.add(item)
+
+	return true
+}
+
+// Union merges the components containing a and b, adding the items if they are not present.
+// It reports whether the components were different before the call.
+func (s *DisjointSet[T]) Union(a, b T) bool {
+	ra, rb := s.find(s.indexOf(a)), s.find(s.indexOf(b))
+	if ra == rb {
+		return false
+	}
+
+	switch {
+	case s.rank[ra] < s.rank[rb]:
+		s.parent[ra] = rb
+	case s.rank[ra] > s.rank[rb]:
+		s.parent[rb] = ra
+	default:
+		s.parent[rb] = ra
+		s.rank[ra]++
+	}
+
+	s.components--
+
+	return true
+}
+
+// Find returns the representative item of the component containing the item.
+// The representative is the same for all items of a component until the next Union call.
+func (s *DisjointSet[T]) Find(item T) (T, bool) {
+	i, ok := s.index[item]
+	if !ok {
+		return *new(T), false
+	}
+
+	return s.items[s.find(i)], true
+}
+
+// Connected reports whether a and b are in the same component.
+func (s *DisjointSet[T]) Connected(a, b T) bool {
+	ia, ok := s.index[a]
+	if !ok {
+		return false
+	}
+
+	ib, ok := s.index[b]
+	if !ok {
+		return false
+	}
+
+	return s.find(ia) == s.find(ib)
+}
+
+// Len returns the number of items.
+func (s *DisjointSet[T]) Len() int {
+	return len(s.items)
+}
+
+// ComponentCount returns the number of components.
+func (s *DisjointSet[T]) ComponentCount() int {
+	return s.components
+}
+
+// Components returns an iterator over the components. Each component is yielded as a new slice
+// with items in the order they were added.
+func (s *DisjointSet[T]) Components() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		groups := make(map[int][]T, s.components)
+		roots := make([]int, 0, s.components)
+
+		for i, item := range s.items {
+			root := s.find(i)
+
+			if _, ok := groups[root]; !ok {
+				roots = append(roots, root)
+			}
+
+			groups[root] = append(groups[root], item)
+		}
+
+		for _, root := range roots {
+			if !yield(groups[root]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items.
+func (s *DisjointSet[T]) Clear() {
+	*s = DisjointSet[T]{}
+}
+
+func (s *DisjointSet[T]) add(item T) int {
+	if s.index == nil {
+		s.index = map[T]int{}
+	}
+
+	i := len(s.items)
+
+	s.index[item] = i
+	s.items = append(s.items, item)
+	s.parent = append(s.parent, i)
+	s.rank = append(s.rank, 0)
+	s.components++
+
+	return i
+}
+
+func (s *DisjointSet[T]) indexOf(item T) int {
+	if i, ok := s.index[item]; ok {
+		return i
+	}
+
+	return s.add(item)
+}
+
+func (s *DisjointSet[T]) find(i int) int {
+	root := i
+	for s.parent[root] != root {
+		root = s.parent[root]
+	}
+
+	// path compression
+	for s.parent[i] != root {
+		s.parent[i], i = root, s.parent[i]
+	}
+
+	return root
+}
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestDisjointSet(t *testing.T) {
+	t.Parallel()
+
+	var s containers.DisjointSet[string]
+
+	_, ok := s.Find("a")
+	require.False(t, ok)
+	require.False(t, s.Connected("a", "a"))
+
+	require.True(t, s.Add("a"))
+	require.False(t, s.Add("a"))
+	require.True(t, s.Connected("a", "a"))
+
+	require.True(t, s.Union("a", "b"))
+	require.True(t, s.Union("c", "d"))
+	require.True(t, s.Union("e", "d"))
+	require.False(t, s.Union("c", "e"))
+	require.True(t, s.Add("f"))
+
+	assert.Equal(t, 6, s.Len())
+	assert.Equal(t, 3, s.ComponentCount())
+
+	assert.True(t, s.Connected("a", "b"))
+	assert.True(t, s.Connected("c", "e"))
+	assert.False(t, s.Connected("a", "c"))
+	assert.False(t, s.Connected("a", "z"))
+
+	rc, ok := s.Find("c")
+	require.True(t, ok)
+
+	re, ok := s.Find("e")
+	require.True(t, ok)
+	assert.Equal(t, rc, re)
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d", "e"}, {"f"}}, slices.Collect(s.Components()))
+
+	require.True(t, s.Union("b", "e"))
+	assert.Equal(t, 2, s.ComponentCount())
+	assert.True(t, s.Connected("a", "d"))
+
+	for component := range s.Components() {
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, component)
+
+		break
+	}
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+	assert.Zero(t, s.ComponentCount())
+}
+
+func TestDisjointSetChain(t *testing.T) {
+	t.Parallel()
+
+	var s containers.DisjointSet[int]
+
+	for i := range 1000 {
+		s.Union(i, i+1)
+	}
+
+	assert.Equal(t, 1, s.ComponentCount())
+	assert.True(t, s.Connected(0, 1000))
+}
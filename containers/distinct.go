@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"fmt"
+	"iter"
+)
+
+// DistinctByRecent is like xiter.DistinctBy, but only remembers the n most recently seen keys.
+//
+// A key is forgotten once n other distinct keys were seen after it, so its element might be yielded again.
+// It panics if n is not positive.
+func DistinctByRecent[K comparable, V any](n int, key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	if n <= 0 {
+		panic(fmt.Sprintf("number of keys to remember must be positive, got %d", n))
+	}
+
+	return func(yield func(V) bool) {
+		var seen OrderedMap[K, struct{}]
+
+		for v := range seq {
+			k := key(v)
+
+			if seen.MoveToEnd(k) {
+				continue
+			}
+
+			if seen.Len() == n {
+				// evict the least recently seen key
+				seen.Delete(seen.head.key)
+			}
+
+			seen.Set(k, struct{}{})
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctByApprox is like xiter.DistinctBy, but remembers keys in a bloom filter sized for capacity keys
+// with the given false positive rate.
+//
+// Duplicates are never yielded, but an element might be wrongly skipped with the probability of fpRate,
+// which grows once more than capacity distinct keys were seen.
+// It panics if capacity is not positive or fpRate is not in (0, 1).
+func DistinctByApprox[K comparable, V any](capacity int, fpRate float64, key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	checkBloomFilterParams(capacity, fpRate)
+
+	return func(yield func(V) bool) {
+		seen := NewBloomFilter[K](capacity, fpRate)
+
+		for v := range seq {
+			k := key(v)
+
+			if seen.MayContain(k) {
+				continue
+			}
+
+			seen.Add(k)
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
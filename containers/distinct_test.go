@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleDistinctByRecent() {
+	identity := func(v int) int { return v }
+
+	// 1 is forgotten after 2 and 3 are seen
+	fmt.Println(slices.Collect(containers.DistinctByRecent(2, identity, slices.Values([]int{1, 2, 3, 1}))))
+
+	// Output:
+	// [1 2 3 1]
+}
+
+func TestDistinctByRecent(t *testing.T) {
+	t.Parallel()
+
+	identity := func(v int) int { return v }
+
+	// recently seen keys are refreshed on every occurrence
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(containers.DistinctByRecent(2, identity, slices.Values([]int{1, 2, 1, 3, 1}))))
+	assert.Equal(t, []int{1, 2, 1, 2}, slices.Collect(containers.DistinctByRecent(1, identity, slices.Values([]int{1, 1, 2, 1, 2, 2}))))
+	assert.Panics(t, func() { containers.DistinctByRecent(0, identity, xiter.Empty[int]) })
+}
+
+func TestDistinctByApprox(t *testing.T) {
+	t.Parallel()
+
+	identity := func(v int) int { return v }
+
+	values := make([]int, 0, 2000)
+
+	for i := range 1000 {
+		values = append(values, i, i)
+	}
+
+	distinct := slices.Collect(containers.DistinctByApprox(1000, 0.01, identity, slices.Values(values)))
+
+	assert.Len(t, slices.Compact(slices.Clone(distinct)), len(distinct), "duplicates must never be yielded")
+	assert.Greater(t, len(distinct), 950)
+
+	// iterating again starts with an empty filter
+	assert.Len(t, slices.Collect(containers.DistinctByApprox(1000, 0.01, identity, slices.Values([]int{1, 1, 2}))), 2)
+
+	assert.Panics(t, func() { containers.DistinctByApprox(0, 0.01, identity, xiter.Empty[int]) })
+	assert.Panics(t, func() { containers.DistinctByApprox(10, 0, identity, xiter.Empty[int]) })
+}
@@ -0,0 +1,366 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Graph is a directed graph.
+//
+// Nodes and edges are kept in insertion order, so all algorithms produce deterministic results.
+// The zero value is an empty graph ready to use.
+type Graph[T comparable] struct {
+	nodes OrderedMap[T, *graphNode[T]]
+	edges int
+}
+
+type graphNode[T comparable] struct {
+	out OrderedMap[T, struct{}]
+	in  OrderedMap[T, struct{}]
+}
+
+// CycleError is returned by Graph.TopoSort when the graph contains a cycle.
+type CycleError[T comparable] struct {
+	// Cycle is the path of the cycle, with the first node repeated at the end.
+	Cycle []T
+}
+
+// Error implements error interface.
+func (e *CycleError[T]) Error() string {
+	path := make([]string, 0, len(e.Cycle))
+
+	for _, n := range e.Cycle {
+		path = append(path, fmt.Sprint(n))
+	}
+
+	return "cycle detected: " + strings.Join(path, " -> ")
+}
+
+// AddNode adds the node to the graph. It reports whether the node was added.
+func (g *Graph[T]) AddNode(n T) bool {
+	if _, ok := g.nodes.Get(n); ok {
+		return false
+	}
+
+	g.nodes.Set(n, &graphNode[T]{})
+
+	return true
+}
+
+// AddEdge adds the edge from -> to, adding the nodes if they are not present.
+// It reports whether the edge was added.
+func (g *Graph[T]) AddEdge(from, to T) bool {
+	fromNode, toNode := g.node(from), g.node(to)
+
+	if _, ok := fromNode.out.Get(to); ok {
+		return false
+	}
+
+	fromNode.out.Set(to, struct{}{})
+	toNode.in.Set(from, struct{}{})
+	g.edges++
+
+	return true
+}
+
+// RemoveEdge removes the edge from -> to. It reports whether the edge was present.
+func (g *Graph[T]) RemoveEdge(from, to T) bool {
+	fromNode, ok := g.nodes.Get(from)
+	if !ok {
+		return false
+	}
+
+	if _, ok = fromNode.out.Get(to); !ok {
+		return false
+	}
+
+	toNode, _ := g.nodes.Get(to)
+
+	fromNode.out.Delete(to)
+	toNode.in.Delete(from)
+	g.edges--
+
+	return true
+}
+
+// RemoveNode removes the node and all its edges. It reports whether the node was present.
+func (g *Graph[T]) RemoveNode(n T) bool {
+	node, ok := g.nodes.Get(n)
+	if !ok {
+		return false
+	}
+
+	for to := range node.out.Keys() {
+		g.RemoveEdge(n, to)
+	}
+
+	for from := range node.in.Keys() {
+		g.RemoveEdge(from, n)
+	}
+
+	g.nodes.Delete(n)
+
+	return true
+}
+
+// HasNode reports whether the node is in the graph.
+func (g *Graph[T]) HasNode(n T) bool {
+	_, ok := g.nodes.Get(n)
+
+	return ok
+}
+
+// HasEdge reports whether the edge from -> to is in the graph.
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	node, ok := g.nodes.Get(from)
+	if !ok {
+		return false
+	}
+
+	_, ok = node.out.Get(to)
+
+	return ok
+}
+
+// Len returns the number of nodes.
+func (g *Graph[T]) Len() int {
+	return g.nodes.Len()
+}
+
+// EdgeCount returns the number of edges.
+func (g *Graph[T]) EdgeCount() int {
+	return g.edges
+}
+
+// Nodes returns an iterator over the nodes in insertion order.
+func (g *Graph[T]) Nodes() iter.Seq[T] {
+	return g.nodes.Keys()
+}
+
+// Successors returns an iterator over the nodes with an edge from n.
+func (g *Graph[T]) Successors(n T) iter.Seq[T] {
+	node, ok := g.nodes.Get(n)
+	if !ok {
+		return func(func(T) bool) {}
+	}
+
+	return node.out.Keys()
+}
+
+// Predecessors returns an iterator over the nodes with an edge to n.
+func (g *Graph[T]) Predecessors(n T) iter.Seq[T] {
+	node, ok := g.nodes.Get(n)
+	if !ok {
+		return func(func(T) bool) {}
+	}
+
+	return node.in.Keys()
+}
+
+// Reachable returns an iterator over the nodes reachable from the given node, in breadth-first order.
+// The node itself is only yielded if it is on a cycle.
+//
+// The graph must not be modified during iteration.
+func (g *Graph[T]) Reachable(from T) iter.Seq[T] {
+	return g.bfs(from, func(node *graphNode[T]) iter.Seq[T] { return node.out.Keys() })
+}
+
+// ReverseReachable returns an iterator over the nodes from which the given node is reachable, in breadth-first order.
+// The node itself is only yielded if it is on a cycle.
+//
+// The graph must not be modified during iteration.
+func (g *Graph[T]) ReverseReachable(to T) iter.Seq[T] {
+	return g.bfs(to, func(node *graphNode[T]) iter.Seq[T] { return node.in.Keys() })
+}
+
+// TopoSort returns the nodes ordered so that every node comes before all nodes it has edges to.
+//
+// Nodes which don't depend on each other keep their insertion order. If the graph contains a cycle,
+// TopoSort returns a *CycleError describing one of the cycles.
+func (g *Graph[T]) TopoSort() ([]T, error) {
+	inDegree := make(map[T]int, g.nodes.Len())
+
+	var queue Deque[T]
+
+	for n, node := range g.nodes.All() {
+		inDegree[n] = node.in.Len()
+
+		if inDegree[n] == 0 {
+			queue.PushBack(n)
+		}
+	}
+
+	sorted := make([]T, 0, g.nodes.Len())
+
+	for queue.Len() > 0 {
+		n, _ := queue.PopFront()
+		sorted = append(sorted, n)
+
+		node, _ := g.nodes.Get(n)
+
+		for to := range node.out.Keys() {
+			inDegree[to]--
+
+			if inDegree[to] == 0 {
+				queue.PushBack(to)
+			}
+		}
+	}
+
+	if len(sorted) == g.nodes.Len() {
+		return sorted, nil
+	}
+
+	return nil, &CycleError[T]{Cycle: g.findCycle(inDegree)}
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph
+// in reverse topological order: no component has edges to components after it.
+func (g *Graph[T]) StronglyConnectedComponents() [][]T {
+	// Tarjan's algorithm
+	var (
+		components [][]T
+		stack      []T
+		counter    int
+	)
+
+	index := make(map[T]int, g.nodes.Len())
+	lowLink := make(map[T]int, g.nodes.Len())
+	onStack := make(map[T]bool, g.nodes.Len())
+
+	var strongConnect func(n T)
+
+	strongConnect = func(n T) {
+		index[n], lowLink[n] = counter, counter
+		counter++
+
+		stack = append(stack, n)
+		onStack[n] = true
+
+		node, _ := g.nodes.Get(n)
+
+		for to := range node.out.Keys() {
+			if _, visited := index[to]; !visited {
+				strongConnect(to)
+
+				lowLink[n] = min(lowLink[n], lowLink[to])
+			} else if onStack[to] {
+				lowLink[n] = min(lowLink[n], index[to])
+			}
+		}
+
+		if lowLink[n] != index[n] {
+			return
+		}
+
+		i := slices.Index(stack, n)
+		component := slices.Clone(stack[i:])
+		stack = stack[:i]
+
+		for _, m := range component {
+			onStack[m] = false
+		}
+
+		components = append(components, component)
+	}
+
+	for n := range g.nodes.Keys() {
+		if _, visited := index[n]; !visited {
+			strongConnect(n)
+		}
+	}
+
+	return components
+}
+
+func (g *Graph[T]) node(n T) *graphNode[T] {
+	node, ok := g.nodes.Get(n)
+	if !ok {
+		node = &graphNode[T]{}
+		g.nodes.Set(n, node)
+	}
+
+	return node
+}
+
+func (g *Graph[T]) bfs(start T, next func(*graphNode[T]) iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		node, ok := g.nodes.Get(start)
+		if !ok {
+			return
+		}
+
+		visited := map[T]struct{}{}
+
+		var queue Deque[*graphNode[T]]
+
+		queue.PushBack(node)
+
+		for queue.Len() > 0 {
+			node, _ = queue.PopFront()
+
+			for n := range next(node) {
+				if _, seen := visited[n]; seen {
+					continue
+				}
+
+				visited[n] = struct{}{}
+
+				if !yield(n) {
+					return
+				}
+
+				nextNode, _ := g.nodes.Get(n)
+				queue.PushBack(nextNode)
+			}
+		}
+	}
+}
+
+// findCycle finds a cycle among the nodes left with non-zero in-degree after Kahn's algorithm.
+//
+// Every such node has a predecessor which is left as well, so walking predecessors must eventually
+// revisit a node.
+func (g *Graph[T]) findCycle(inDegree map[T]int) []T {
+	var start T
+
+	for n := range g.nodes.Keys() {
+		if inDegree[n] > 0 {
+			start = n
+
+			break
+		}
+	}
+
+	position := map[T]int{}
+
+	var path []T
+
+	for n := start; ; {
+		if i, ok := position[n]; ok {
+			cycle := append(path[i:], n) //nolint:gocritic
+			slices.Reverse(cycle)
+
+			return cycle
+		}
+
+		position[n] = len(path)
+		path = append(path, n)
+
+		node, _ := g.nodes.Get(n)
+
+		for from := range node.in.Keys() {
+			if inDegree[from] > 0 {
+				n = from
+
+				break
+			}
+		}
+	}
+}
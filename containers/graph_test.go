@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	var g containers.Graph[string]
+
+	require.True(t, g.AddNode("a"))
+	require.False(t, g.AddNode("a"))
+	require.True(t, g.AddEdge("a", "b"))
+	require.False(t, g.AddEdge("a", "b"))
+	require.True(t, g.AddEdge("b", "c"))
+	require.True(t, g.AddEdge("a", "c"))
+	require.True(t, g.AddEdge("d", "c"))
+
+	assert.Equal(t, 4, g.Len())
+	assert.Equal(t, 4, g.EdgeCount())
+	assert.True(t, g.HasNode("d"))
+	assert.True(t, g.HasEdge("a", "c"))
+	assert.False(t, g.HasEdge("c", "a"))
+	assert.False(t, g.HasEdge("z", "a"))
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(g.Nodes()))
+	assert.Equal(t, []string{"b", "c"}, slices.Collect(g.Successors("a")))
+	assert.Equal(t, []string{"b", "a", "d"}, slices.Collect(g.Predecessors("c")))
+	assert.Empty(t, slices.Collect(g.Successors("z")))
+	assert.Empty(t, slices.Collect(g.Predecessors("z")))
+
+	assert.Equal(t, []string{"b", "c"}, slices.Collect(g.Reachable("a")))
+	assert.Equal(t, []string{"b", "a", "d"}, slices.Collect(g.ReverseReachable("c")))
+	assert.Empty(t, slices.Collect(g.Reachable("c")))
+	assert.Empty(t, slices.Collect(g.Reachable("z")))
+
+	require.False(t, g.RemoveEdge("c", "a"))
+	require.False(t, g.RemoveEdge("z", "a"))
+	require.True(t, g.RemoveEdge("a", "c"))
+	assert.Equal(t, 3, g.EdgeCount())
+
+	require.True(t, g.RemoveNode("c"))
+	require.False(t, g.RemoveNode("c"))
+	assert.Equal(t, 1, g.EdgeCount())
+	assert.Equal(t, []string{"a", "b", "d"}, slices.Collect(g.Nodes()))
+	assert.Empty(t, slices.Collect(g.Successors("b")))
+}
+
+func TestGraphTopoSort(t *testing.T) {
+	t.Parallel()
+
+	var g containers.Graph[string]
+
+	g.AddNode("network")
+	g.AddNode("udevd")
+	g.AddEdge("containerd", "kubelet")
+	g.AddEdge("network", "kubelet")
+	g.AddEdge("network", "containerd")
+	g.AddEdge("udevd", "network")
+
+	sorted, err := g.TopoSort()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"udevd", "network", "containerd", "kubelet"}, sorted)
+
+	g.AddEdge("kubelet", "apid")
+	g.AddEdge("apid", "network")
+
+	_, err = g.TopoSort()
+	require.Error(t, err)
+
+	var cycleErr *containers.CycleError[string]
+
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"network", "containerd", "kubelet", "apid", "network"}, cycleErr.Cycle)
+	assert.EqualError(t, err, "cycle detected: network -> containerd -> kubelet -> apid -> network")
+
+	var selfLoop containers.Graph[int]
+
+	selfLoop.AddEdge(1, 2)
+	selfLoop.AddEdge(2, 2)
+
+	_, err = selfLoop.TopoSort()
+	require.Error(t, err)
+	assert.EqualError(t, err, "cycle detected: 2 -> 2")
+}
+
+func TestGraphStronglyConnectedComponents(t *testing.T) {
+	t.Parallel()
+
+	var g containers.Graph[int]
+
+	for _, edge := range [][2]int{
+		{1, 2}, {2, 3}, {3, 1},
+		{3, 4},
+		{4, 5}, {5, 4},
+		{6, 5}, {6, 7},
+	} {
+		g.AddEdge(edge[0], edge[1])
+	}
+
+	assert.Equal(t, [][]int{{4, 5}, {1, 2, 3}, {7}, {6}}, g.StronglyConnectedComponents())
+	assert.Equal(t, []int{2, 3, 1, 4, 5}, slices.Collect(g.Reachable(1)))
+}
@@ -0,0 +1,315 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
+
+// Interval is a half-open interval [Lo, Hi).
+//
+// Half-open intervals compose without gaps: [1, 3) and [3, 5) are adjacent. An inclusive integer
+// range such as ports 80-90 is represented as [80, 91).
+type Interval[T cmp.Ordered] struct {
+	Lo T
+	Hi T
+}
+
+// Empty reports whether the interval contains no points.
+func (iv Interval[T]) Empty() bool {
+	return iv.Lo >= iv.Hi
+}
+
+// Contains reports whether the point is within the interval.
+func (iv Interval[T]) Contains(p T) bool {
+	return iv.Lo <= p && p < iv.Hi
+}
+
+// Overlaps reports whether the intervals have at least one point in common.
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return iv.Lo < other.Hi && other.Lo < iv.Hi && !iv.Empty() && !other.Empty()
+}
+
+// String implements fmt.Stringer.
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Lo, iv.Hi)
+}
+
+func compareIntervals[T cmp.Ordered](a, b Interval[T]) int {
+	return cmp.Or(cmp.Compare(a.Lo, b.Lo), cmp.Compare(a.Hi, b.Hi))
+}
+
+// IntervalTree maps intervals to values, and finds intervals containing a point or overlapping an interval.
+//
+// It is an AVL tree ordered by interval start and augmented with the maximum interval end of each subtree,
+// so all operations are O(log n), and queries are O(log n + k) where k is the number of results.
+// The zero value is an empty tree ready to use.
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root *intervalTreeNode[T, V]
+	len  int
+}
+
+type intervalTreeNode[T cmp.Ordered, V any] struct {
+	left   *intervalTreeNode[T, V]
+	right  *intervalTreeNode[T, V]
+	iv     Interval[T]
+	maxHi  T
+	value  V
+	height int
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[T, V]) Len() int {
+	return t.len
+}
+
+// Insert sets the value for the given interval. It reports whether the interval was already present.
+func (t *IntervalTree[T, V]) Insert(iv Interval[T], val V) bool {
+	var replaced bool
+
+	t.root, replaced = t.root.insert(iv, val)
+
+	if !replaced {
+		t.len++
+	}
+
+	return replaced
+}
+
+// Get returns the value for the given interval.
+func (t *IntervalTree[T, V]) Get(iv Interval[T]) (V, bool) {
+	for n := t.root; n != nil; {
+		switch c := compareIntervals(iv, n.iv); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+
+	return *new(V), false
+}
+
+// Delete removes the given interval. It reports whether the interval was present.
+func (t *IntervalTree[T, V]) Delete(iv Interval[T]) bool {
+	var deleted bool
+
+	t.root, deleted = t.root.delete(iv)
+
+	if deleted {
+		t.len--
+	}
+
+	return deleted
+}
+
+// Clear removes all intervals from the tree.
+func (t *IntervalTree[T, V]) Clear() {
+	t.root = nil
+	t.len = 0
+}
+
+// All returns an iterator over all intervals and their values ordered by interval start.
+//
+// The tree must not be modified during iteration.
+func (t *IntervalTree[T, V]) All() iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		t.root.all(yield)
+	}
+}
+
+// Stab returns an iterator over the intervals containing the point, ordered by interval start.
+//
+// The tree must not be modified during iteration.
+func (t *IntervalTree[T, V]) Stab(p T) iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		t.root.stab(p, yield)
+	}
+}
+
+// Overlapping returns an iterator over the intervals overlapping the given interval, ordered by interval start.
+//
+// The tree must not be modified during iteration.
+func (t *IntervalTree[T, V]) Overlapping(iv Interval[T]) iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		if iv.Empty() {
+			return
+		}
+
+		t.root.overlapping(iv, yield)
+	}
+}
+
+func (n *intervalTreeNode[T, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *intervalTreeNode[T, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.maxHi = n.iv.Hi
+
+	if n.left != nil {
+		n.maxHi = max(n.maxHi, n.left.maxHi)
+	}
+
+	if n.right != nil {
+		n.maxHi = max(n.maxHi, n.right.maxHi)
+	}
+}
+
+func (n *intervalTreeNode[T, V]) rotateLeft() *intervalTreeNode[T, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+
+	n.update()
+	r.update()
+
+	return r
+}
+
+func (n *intervalTreeNode[T, V]) rotateRight() *intervalTreeNode[T, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+
+	n.update()
+	l.update()
+
+	return l
+}
+
+func (n *intervalTreeNode[T, V]) rebalance() *intervalTreeNode[T, V] {
+	n.update()
+
+	switch balance := n.left.getHeight() - n.right.getHeight(); {
+	case balance > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+
+		return n.rotateLeft()
+	default:
+		return n
+	}
+}
+
+func (n *intervalTreeNode[T, V]) insert(iv Interval[T], val V) (*intervalTreeNode[T, V], bool) {
+	if n == nil {
+		return &intervalTreeNode[T, V]{iv: iv, maxHi: iv.Hi, value: val, height: 1}, false
+	}
+
+	var replaced bool
+
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left, replaced = n.left.insert(iv, val)
+	case c > 0:
+		n.right, replaced = n.right.insert(iv, val)
+	default:
+		n.value = val
+
+		return n, true
+	}
+
+	return n.rebalance(), replaced
+}
+
+func (n *intervalTreeNode[T, V]) delete(iv Interval[T]) (*intervalTreeNode[T, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left, deleted = n.left.delete(iv)
+	case c > 0:
+		n.right, deleted = n.right.delete(iv)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+
+		if n.right == nil {
+			return n.left, true
+		}
+
+		// replace the node with its successor
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+
+		n.iv, n.value = succ.iv, succ.value
+		n.right, _ = n.right.delete(succ.iv)
+		deleted = true
+	}
+
+	return n.rebalance(), deleted
+}
+
+func (n *intervalTreeNode[T, V]) all(yield func(Interval[T], V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.all(yield) && yield(n.iv, n.value) && n.right.all(yield)
+}
+
+func (n *intervalTreeNode[T, V]) stab(p T, yield func(Interval[T], V) bool) bool {
+	if n == nil || n.maxHi <= p {
+		return true
+	}
+
+	if !n.left.stab(p, yield) {
+		return false
+	}
+
+	if n.iv.Lo > p {
+		// everything to the right starts after p
+		return true
+	}
+
+	if p < n.iv.Hi && !yield(n.iv, n.value) {
+		return false
+	}
+
+	return n.right.stab(p, yield)
+}
+
+func (n *intervalTreeNode[T, V]) overlapping(iv Interval[T], yield func(Interval[T], V) bool) bool {
+	if n == nil || n.maxHi <= iv.Lo {
+		return true
+	}
+
+	if !n.left.overlapping(iv, yield) {
+		return false
+	}
+
+	if n.iv.Lo >= iv.Hi {
+		// everything to the right starts after iv
+		return true
+	}
+
+	if n.iv.Overlaps(iv) && !yield(n.iv, n.value) {
+		return false
+	}
+
+	return n.right.overlapping(iv, yield)
+}
@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/xiter"
+)
+
+type iv = containers.Interval[int]
+
+func TestInterval(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, iv{Lo: 1, Hi: 1}.Empty())
+	assert.False(t, iv{Lo: 1, Hi: 2}.Empty())
+	assert.True(t, iv{Lo: 1, Hi: 3}.Contains(1))
+	assert.False(t, iv{Lo: 1, Hi: 3}.Contains(3))
+	assert.True(t, iv{Lo: 1, Hi: 3}.Overlaps(iv{Lo: 2, Hi: 5}))
+	assert.False(t, iv{Lo: 1, Hi: 3}.Overlaps(iv{Lo: 3, Hi: 5}))
+	assert.False(t, iv{Lo: 1, Hi: 3}.Overlaps(iv{Lo: 2, Hi: 2}))
+	assert.Equal(t, "[1, 3)", iv{Lo: 1, Hi: 3}.String())
+}
+
+func TestIntervalTree(t *testing.T) {
+	t.Parallel()
+
+	var tree containers.IntervalTree[int, string]
+
+	require.False(t, tree.Insert(iv{Lo: 0, Hi: 10}, "a"))
+	require.False(t, tree.Insert(iv{Lo: 5, Hi: 7}, "b"))
+	require.False(t, tree.Insert(iv{Lo: 8, Hi: 20}, "c"))
+	require.False(t, tree.Insert(iv{Lo: 15, Hi: 16}, "d"))
+	require.True(t, tree.Insert(iv{Lo: 5, Hi: 7}, "bb"))
+
+	assert.Equal(t, 4, tree.Len())
+
+	val, ok := tree.Get(iv{Lo: 5, Hi: 7})
+	require.True(t, ok)
+	assert.Equal(t, "bb", val)
+
+	_, ok = tree.Get(iv{Lo: 5, Hi: 8})
+	require.False(t, ok)
+
+	assert.Equal(t, []string{"a", "bb", "c", "d"}, slices.Collect(xiter.Values(tree.All())))
+	assert.Equal(t, []string{"a", "bb"}, slices.Collect(xiter.Values(tree.Stab(5))))
+	assert.Equal(t, []string{"a", "c"}, slices.Collect(xiter.Values(tree.Stab(9))))
+	assert.Empty(t, slices.Collect(xiter.Values(tree.Stab(20))))
+	assert.Equal(t, []string{"c", "d"}, slices.Collect(xiter.Values(tree.Overlapping(iv{Lo: 10, Hi: 16}))))
+	assert.Empty(t, slices.Collect(xiter.Values(tree.Overlapping(iv{Lo: 10, Hi: 10}))))
+
+	require.True(t, tree.Delete(iv{Lo: 0, Hi: 10}))
+	require.False(t, tree.Delete(iv{Lo: 0, Hi: 10}))
+
+	assert.Equal(t, []string{"c"}, slices.Collect(xiter.Values(tree.Stab(9))))
+
+	tree.Clear()
+	assert.Zero(t, tree.Len())
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	t.Parallel()
+
+	var tree containers.IntervalTree[int, int]
+
+	expected := map[iv]int{}
+
+	for i := range 5000 {
+		lo := rand.IntN(1000)
+		interval := iv{Lo: lo, Hi: lo + 1 + rand.IntN(50)}
+
+		if rand.IntN(4) == 0 {
+			_, wantOK := expected[interval]
+			require.Equal(t, wantOK, tree.Delete(interval))
+
+			delete(expected, interval)
+
+			continue
+		}
+
+		tree.Insert(interval, i)
+
+		expected[interval] = i
+	}
+
+	require.Equal(t, len(expected), tree.Len())
+
+	for range 200 {
+		p := rand.IntN(1100)
+		query := iv{Lo: p, Hi: p + rand.IntN(30)}
+
+		var wantStab, wantOverlap []iv
+
+		for interval := range expected {
+			if interval.Contains(p) {
+				wantStab = append(wantStab, interval)
+			}
+
+			if interval.Overlaps(query) {
+				wantOverlap = append(wantOverlap, interval)
+			}
+		}
+
+		slices.SortFunc(wantStab, compareIv)
+		slices.SortFunc(wantOverlap, compareIv)
+
+		assert.Equal(t, wantStab, slices.Collect(xiter.Keys(tree.Stab(p))))
+		assert.Equal(t, wantOverlap, slices.Collect(xiter.Keys(tree.Overlapping(query))))
+	}
+}
+
+func compareIv(a, b iv) int {
+	if a.Lo != b.Lo {
+		return a.Lo - b.Lo
+	}
+
+	return a.Hi - b.Hi
+}
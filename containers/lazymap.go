@@ -5,6 +5,14 @@
 // Package containers provides generic containers.
 package containers
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBiMapConflict is returned by BiMap.TrySet when the key or the value is already taken.
+var ErrBiMapConflict = errors.New("bimap conflict")
+
 // LazyBiMap is like BiMap but creates values on demand.
 type LazyBiMap[K comparable, V comparable] struct {
 	Creator func(K) (V, error)
@@ -95,19 +103,49 @@ func (m *BiMap[K, V]) GetInverse(val V) (K, bool) {
 }
 
 // Set sets the value for the given key.
-func (m *BiMap[K, V]) Set(key K, val V) {
+//
+// If the value was already associated with a different key, that key is removed from the map
+// and returned as evicted, with ok set to true.
+func (m *BiMap[K, V]) Set(key K, val V) (evicted K, ok bool) {
 	if m.k2v == nil {
 		m.k2v = map[K]V{}
 		m.v2k = map[V]K{}
 	}
 
 	// Ensure that there is only one key per value
-	if prevKey, ok := m.v2k[val]; ok && prevKey != key {
+	if prevKey, found := m.v2k[val]; found && prevKey != key {
 		delete(m.k2v, prevKey)
+
+		evicted, ok = prevKey, true
+	}
+
+	// Ensure that there is only one value per key
+	if prevVal, found := m.k2v[key]; found && prevVal != val {
+		delete(m.v2k, prevVal)
 	}
 
 	m.k2v[key] = val
 	m.v2k[val] = key
+
+	return evicted, ok
+}
+
+// TrySet sets the value for the given key only if neither the key nor the value are
+// already associated with something else.
+//
+// Setting the exact same key-value pair again is not a conflict. Returned errors wrap ErrBiMapConflict.
+func (m *BiMap[K, V]) TrySet(key K, val V) error {
+	if prevVal, ok := m.k2v[key]; ok && prevVal != val {
+		return fmt.Errorf("%w: key %v is already mapped to value %v", ErrBiMapConflict, key, prevVal)
+	}
+
+	if prevKey, ok := m.v2k[val]; ok && prevKey != key {
+		return fmt.Errorf("%w: value %v is already mapped to key %v", ErrBiMapConflict, val, prevKey)
+	}
+
+	m.Set(key, val)
+
+	return nil
 }
 
 // Remove deletes the value for the given key.
@@ -158,6 +158,62 @@ func TestLazyBiMap(t *testing.T) {
 	})
 }
 
+func TestBiMap(t *testing.T) {
+	t.Run("should report evicted key", func(t *testing.T) {
+		var m containers.BiMap[string, int]
+
+		_, evicted := m.Set("a", 1)
+		require.False(t, evicted)
+
+		key, evicted := m.Set("b", 1)
+		require.True(t, evicted)
+		assert.Equal(t, "a", key)
+
+		_, ok := m.Get("a")
+		require.False(t, ok)
+
+		key, ok = m.GetInverse(1)
+		require.True(t, ok)
+		assert.Equal(t, "b", key)
+	})
+
+	t.Run("should drop old value on key overwrite", func(t *testing.T) {
+		var m containers.BiMap[string, int]
+
+		m.Set("a", 1)
+
+		_, evicted := m.Set("a", 2)
+		require.False(t, evicted)
+
+		_, ok := m.GetInverse(1)
+		require.False(t, ok)
+
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("should refuse conflicting values", func(t *testing.T) {
+		var m containers.BiMap[string, int]
+
+		require.NoError(t, m.TrySet("a", 1))
+		require.NoError(t, m.TrySet("a", 1))
+
+		err := m.TrySet("b", 1)
+		require.ErrorIs(t, err, containers.ErrBiMapConflict)
+		assert.ErrorContains(t, err, "value 1 is already mapped to key a")
+
+		err = m.TrySet("a", 2)
+		require.ErrorIs(t, err, containers.ErrBiMapConflict)
+		assert.ErrorContains(t, err, "key a is already mapped to value 1")
+
+		val, ok := m.Get("a")
+		require.True(t, ok)
+		assert.Equal(t, 1, val)
+
+		_, ok = m.Get("b")
+		require.False(t, ok)
+	})
+}
+
 func TestLazyMap(t *testing.T) {
 	prev := 0
 
@@ -4,11 +4,14 @@
 
 package containers
 
-import "sync"
+import (
+	"maps"
+	"sync"
+)
 
 // ConcurrentMap is a map that can be safely accessed from multiple goroutines.
 type ConcurrentMap[K comparable, V any] struct {
-	m  map[K]V
+	m  lockedMap[K, V]
 	mx sync.Mutex
 }
 
@@ -32,11 +35,7 @@ func (m *ConcurrentMap[K, V]) GetOrCreate(key K, val V) (V, bool) {
 		return res, true
 	}
 
-	if m.m == nil {
-		m.m = map[K]V{}
-	}
-
-	m.m[key] = val
+	m.m.set(key, val)
 
 	return val, false
 }
@@ -50,19 +49,7 @@ func (m *ConcurrentMap[K, V]) GetOrCall(key K, fn func() V) (V, bool) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	if res, ok := m.m[key]; ok {
-		return res, true
-	}
-
-	if m.m == nil {
-		m.m = map[K]V{}
-	}
-
-	val := fn()
-
-	m.m[key] = val
-
-	return val, false
+	return m.m.getOrCall(key, fn)
 }
 
 // Set sets the value for the given key.
@@ -70,11 +57,7 @@ func (m *ConcurrentMap[K, V]) Set(key K, val V) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	if m.m == nil {
-		m.m = map[K]V{}
-	}
-
-	m.m[key] = val
+	m.m.set(key, val)
 }
 
 // Remove removes the value for the given key.
@@ -82,10 +65,6 @@ func (m *ConcurrentMap[K, V]) Remove(key K) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	if m.m == nil {
-		return
-	}
-
 	delete(m.m, key)
 }
 
@@ -94,17 +73,29 @@ func (m *ConcurrentMap[K, V]) RemoveAndGet(key K) (V, bool) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	if m.m == nil {
-		return *new(V), false
-	}
+	return m.m.removeAndGet(key)
+}
 
-	val, ok := m.m[key]
-	delete(m.m, key)
+// Update atomically replaces the value for the key with the result of fn, removing the key if fn returns keep=false.
+// The function fn is called under the lock and must not access the map.
+func (m *ConcurrentMap[K, V]) Update(key K, fn func(val V, exists bool) (newVal V, keep bool)) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
 
-	return val, ok
+	return m.m.update(key, fn)
+}
+
+// Snapshot returns a copy of the map. The copy is nil if the map is empty.
+func (m *ConcurrentMap[K, V]) Snapshot() map[K]V {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return m.m.snapshot()
 }
 
-// ForEach calls the given function for each key-value pair.
+// ForEach calls the given function for each key-value pair under the lock.
+//
+// The function f must not access the map, as that deadlocks; use Snapshot to iterate without holding the lock.
 func (m *ConcurrentMap[K, V]) ForEach(f func(K, V)) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
@@ -119,15 +110,7 @@ func (m *ConcurrentMap[K, V]) FilterInPlace(f func(K, V) bool) {
 	m.mx.Lock()
 	defer m.mx.Unlock()
 
-	if m.m == nil {
-		return
-	}
-
-	for k, v := range m.m {
-		if !f(k, v) {
-			delete(m.m, k)
-		}
-	}
+	m.m.filterInPlace(f)
 }
 
 // Len returns the number of elements in the map.
@@ -153,3 +136,61 @@ func (m *ConcurrentMap[K, V]) Reset() {
 
 	m.m = nil
 }
+
+// lockedMap implements the operations shared by ConcurrentMap and RWConcurrentMap.
+// The caller must hold the lock.
+type lockedMap[K comparable, V any] map[K]V
+
+func (m *lockedMap[K, V]) getOrCall(key K, fn func() V) (V, bool) {
+	if res, ok := (*m)[key]; ok {
+		return res, true
+	}
+
+	val := fn()
+
+	m.set(key, val)
+
+	return val, false
+}
+
+func (m *lockedMap[K, V]) set(key K, val V) {
+	if *m == nil {
+		*m = lockedMap[K, V]{}
+	}
+
+	(*m)[key] = val
+}
+
+func (m *lockedMap[K, V]) removeAndGet(key K) (V, bool) {
+	val, ok := (*m)[key]
+	delete(*m, key)
+
+	return val, ok
+}
+
+func (m *lockedMap[K, V]) update(key K, fn func(val V, exists bool) (newVal V, keep bool)) (V, bool) {
+	val, ok := (*m)[key]
+
+	newVal, keep := fn(val, ok)
+	if !keep {
+		delete(*m, key)
+
+		return newVal, false
+	}
+
+	m.set(key, newVal)
+
+	return newVal, true
+}
+
+func (m *lockedMap[K, V]) snapshot() map[K]V {
+	if len(*m) == 0 {
+		return nil
+	}
+
+	return maps.Clone(map[K]V(*m))
+}
+
+func (m *lockedMap[K, V]) filterInPlace(f func(K, V) bool) {
+	maps.DeleteFunc(*m, func(k K, v V) bool { return !f(k, v) })
+}
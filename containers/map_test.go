@@ -125,6 +125,62 @@ func TestConcurrentMap(t *testing.T) {
 
 		require.Equal(t, 2, m.Len())
 	})
+
+	t.Run("should update value atomically", func(t *testing.T) {
+		t.Parallel()
+
+		m := containers.ConcurrentMap[string, int]{}
+
+		var wg sync.WaitGroup
+
+		for range 100 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				m.Update("counter", func(val int, _ bool) (int, bool) { return val + 1, true })
+			}()
+		}
+
+		wg.Wait()
+
+		val, ok := m.Get("counter")
+		require.True(t, ok)
+		require.Equal(t, 100, val)
+
+		val, kept := m.Update("counter", func(val int, exists bool) (int, bool) {
+			require.True(t, exists)
+
+			return val, false
+		})
+		require.False(t, kept)
+		require.Equal(t, 100, val)
+
+		_, ok = m.Get("counter")
+		require.False(t, ok)
+	})
+
+	t.Run("should return snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		m := containers.ConcurrentMap[int, int]{}
+		require.Nil(t, m.Snapshot())
+
+		m.Set(1, 1)
+		m.Set(2, 2)
+
+		snapshot := m.Snapshot()
+		require.Equal(t, map[int]int{1: 1, 2: 2}, snapshot)
+
+		// the snapshot can be used to modify the map while iterating
+		for k := range snapshot {
+			m.Remove(k)
+		}
+
+		require.Zero(t, m.Len())
+		require.Len(t, snapshot, 2)
+	})
 }
 
 func TestConcurrentMap_GetOrCall(t *testing.T) {
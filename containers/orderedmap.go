@@ -0,0 +1,394 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// OrderedMap is a map which remembers the order in which keys were inserted.
+//
+// Entries are kept in a doubly linked list, so lookups, insertions, deletions and moves are O(1).
+// Iteration, as well as JSON and YAML marshaling, follows the insertion order.
+// The zero value is an empty map ready to use.
+//
+//nolint:recvcheck
+type OrderedMap[K comparable, V any] struct {
+	m    map[K]*orderedMapEntry[K, V]
+	head *orderedMapEntry[K, V]
+	tail *orderedMapEntry[K, V]
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	prev    *orderedMapEntry[K, V]
+	next    *orderedMapEntry[K, V]
+	key     K
+	value   V
+	deleted bool
+}
+
+// Get returns the value for the given key.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	e, ok := m.m[key]
+	if !ok {
+		return *new(V), false
+	}
+
+	return e.value, true
+}
+
+// Set sets the value for the given key.
+//
+// New keys are appended to the end of the map, existing keys keep their position.
+func (m *OrderedMap[K, V]) Set(key K, val V) {
+	if e, ok := m.m[key]; ok {
+		e.value = val
+
+		return
+	}
+
+	if m.m == nil {
+		m.m = map[K]*orderedMapEntry[K, V]{}
+	}
+
+	e := &orderedMapEntry[K, V]{key: key, value: val}
+	m.m[key] = e
+
+	m.pushBack(e)
+}
+
+// Delete removes the value for the given key.
+func (m *OrderedMap[K, V]) Delete(key K) {
+	e, ok := m.m[key]
+	if !ok {
+		return
+	}
+
+	delete(m.m, key)
+	m.unlink(e)
+
+	e.deleted = true
+}
+
+// MoveToEnd moves the given key to the end of the map.
+// It returns false if the key is not present.
+func (m *OrderedMap[K, V]) MoveToEnd(key K) bool {
+	e, ok := m.m[key]
+	if !ok {
+		return false
+	}
+
+	if e != m.tail {
+		m.unlink(e)
+		m.pushBack(e)
+	}
+
+	return true
+}
+
+// MoveToFront moves the given key to the front of the map.
+// It returns false if the key is not present.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	e, ok := m.m[key]
+	if !ok {
+		return false
+	}
+
+	if e != m.head {
+		m.unlink(e)
+		m.pushFront(e)
+	}
+
+	return true
+}
+
+// Len returns the number of key-value pairs.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.m)
+}
+
+// Clear removes all key-value pairs.
+func (m *OrderedMap[K, V]) Clear() {
+	// mark entries as deleted for iterators in progress
+	for e := m.head; e != nil; e = e.next {
+		e.deleted = true
+	}
+
+	m.m = nil
+	m.head = nil
+	m.tail = nil
+}
+
+// All returns an iterator over key-value pairs in insertion order.
+//
+// It is safe to delete keys during iteration.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if e.deleted {
+				continue
+			}
+
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs in reverse insertion order.
+//
+// It is safe to delete keys during iteration.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.tail; e != nil; e = e.prev {
+			if e.deleted {
+				continue
+			}
+
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in insertion order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler. Keys are encoded in insertion order.
+//
+// Keys are converted to JSON object keys using the same rules as encoding/json uses for maps.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			buf.WriteByte(',')
+		}
+
+		key, err := marshalJSONKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of the map, keeping
+// the order of keys from the JSON object.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	m.Clear()
+
+	if tok == nil {
+		return nil
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("unexpected JSON token %v, expected object", tok)
+	}
+
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := unmarshalJSONKey[K](tok.(string)) //nolint:errcheck,forcetypeassert
+		if err != nil {
+			return err
+		}
+
+		var val V
+
+		if err = dec.Decode(&val); err != nil {
+			return err
+		}
+
+		m.Set(key, val)
+	}
+
+	_, err = dec.Token()
+
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler. Keys are encoded in insertion order.
+func (m OrderedMap[K, V]) MarshalYAML() (any, error) {
+	node := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Tag:     "!!map",
+		Content: make([]*yaml.Node, 0, 2*m.Len()),
+	}
+
+	for e := m.head; e != nil; e = e.next {
+		var keyNode, valNode yaml.Node
+
+		if err := keyNode.Encode(e.key); err != nil {
+			return nil, err
+		}
+
+		if err := valNode.Encode(e.value); err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &keyNode, &valNode)
+	}
+
+	return node, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. It replaces the contents of the map, keeping
+// the order of keys from the YAML mapping.
+func (m *OrderedMap[K, V]) UnmarshalYAML(node *yaml.Node) error {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	m.Clear()
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: unexpected YAML node, expected mapping", node.Line)
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		var (
+			key K
+			val V
+		)
+
+		if err := node.Content[i].Decode(&key); err != nil {
+			return err
+		}
+
+		if err := node.Content[i+1].Decode(&val); err != nil {
+			return err
+		}
+
+		m.Set(key, val)
+	}
+
+	return nil
+}
+
+func (m *OrderedMap[K, V]) pushBack(e *orderedMapEntry[K, V]) {
+	e.prev, e.next = m.tail, nil
+
+	if m.tail != nil {
+		m.tail.next = e
+	} else {
+		m.head = e
+	}
+
+	m.tail = e
+}
+
+func (m *OrderedMap[K, V]) pushFront(e *orderedMapEntry[K, V]) {
+	e.prev, e.next = nil, m.head
+
+	if m.head != nil {
+		m.head.prev = e
+	} else {
+		m.tail = e
+	}
+
+	m.head = e
+}
+
+// unlink removes the entry from the list. It keeps e.next and e.prev intact, so iterators
+// positioned at a deleted entry can still advance, skipping other deleted entries on the way.
+func (m *OrderedMap[K, V]) unlink(e *orderedMapEntry[K, V]) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		m.head = e.next
+	}
+
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		m.tail = e.prev
+	}
+}
+
+// marshalJSONKey encodes the key as a quoted JSON object key, following encoding/json rules for map keys.
+func marshalJSONKey[K comparable](key K) ([]byte, error) {
+	data, err := json.Marshal(map[K]struct{}{key: {}})
+	if err != nil {
+		return nil, err
+	}
+
+	// data is {"<key>":{}}
+	return data[1 : len(data)-len(":{}}")], nil
+}
+
+// unmarshalJSONKey decodes the JSON object key, following encoding/json rules for map keys.
+func unmarshalJSONKey[K comparable](key string) (K, error) {
+	quoted, err := json.Marshal(key)
+	if err != nil {
+		return *new(K), err
+	}
+
+	var m map[K]struct{}
+
+	if err = json.Unmarshal(append(append([]byte{'{'}, quoted...), ":{}}"...), &m); err != nil {
+		return *new(K), err
+	}
+
+	for k := range m {
+		return k, nil
+	}
+
+	return *new(K), fmt.Errorf("failed to decode JSON key %q", key)
+}
@@ -0,0 +1,210 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	var m containers.OrderedMap[string, int]
+
+	_, ok := m.Get("a")
+	require.False(t, ok)
+
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+
+	val, ok := m.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 10, val)
+	assert.Equal(t, 3, m.Len())
+
+	assert.Equal(t, []string{"c", "a", "b"}, slices.Collect(m.Keys()))
+	assert.Equal(t, []int{3, 10, 2}, slices.Collect(m.Values()))
+
+	require.True(t, m.MoveToEnd("c"))
+	require.True(t, m.MoveToFront("b"))
+	require.False(t, m.MoveToEnd("z"))
+	require.False(t, m.MoveToFront("z"))
+
+	assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(m.Keys()))
+
+	var backward []string
+
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+
+	assert.Equal(t, []string{"c", "a", "b"}, backward)
+
+	for k := range m.All() {
+		if k == "b" || k == "c" {
+			m.Delete(k)
+		}
+	}
+
+	assert.Equal(t, map[string]int{"a": 10}, maps.Collect(m.All()))
+
+	// deleting keys the iterator has not reached yet
+	for _, k := range []string{"b", "c", "d"} {
+		m.Set(k, 0)
+	}
+
+	var visited []string
+
+	for k := range m.All() {
+		visited = append(visited, k)
+
+		if k == "a" {
+			m.Delete("a")
+			m.Delete("b")
+		}
+	}
+
+	assert.Equal(t, []string{"a", "c", "d"}, visited)
+
+	visited = nil
+
+	for k := range m.Backward() {
+		visited = append(visited, k)
+
+		if k == "d" {
+			m.Delete("c")
+		}
+	}
+
+	assert.Equal(t, []string{"d"}, visited)
+
+	m.Set("a", 10)
+	m.Set("b", 20)
+
+	visited = nil
+
+	for k := range m.All() {
+		visited = append(visited, k)
+
+		m.Clear()
+	}
+
+	assert.Equal(t, []string{"d"}, visited)
+
+	m.Set("a", 10)
+
+	m.Delete("a")
+	m.Delete("z")
+
+	assert.Zero(t, m.Len())
+	assert.Empty(t, slices.Collect(m.Keys()))
+
+	m.Set("d", 4)
+	m.Clear()
+
+	assert.Zero(t, m.Len())
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	t.Parallel()
+
+	var m containers.OrderedMap[string, []int]
+
+	m.Set("zeta", []int{1})
+	m.Set("alpha", nil)
+	m.Set("mid", []int{2, 3})
+
+	data, err := json.Marshal(&m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"zeta":[1],"alpha":null,"mid":[2,3]}`, string(data))
+
+	var decoded containers.OrderedMap[string, []int]
+
+	require.NoError(t, json.Unmarshal([]byte(`{"b":[1],"a":[2],"c":[]}`), &decoded))
+	assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(decoded.Keys()))
+
+	require.Error(t, json.Unmarshal([]byte(`[1, 2]`), &decoded))
+
+	var intKeys containers.OrderedMap[int, string]
+
+	require.NoError(t, json.Unmarshal([]byte(`{"3":"c","1":"a"}`), &intKeys))
+	assert.Equal(t, []int{3, 1}, slices.Collect(intKeys.Keys()))
+
+	data, err = json.Marshal(&intKeys)
+	require.NoError(t, err)
+	assert.Equal(t, `{"3":"c","1":"a"}`, string(data))
+
+	require.Error(t, json.Unmarshal([]byte(`{"x":"c"}`), &intKeys))
+}
+
+func TestOrderedMapYAML(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Sections *containers.OrderedMap[string, int] `yaml:"sections"`
+	}
+
+	in := "sections:\n    zeta: 1\n    alpha: 2\n    mid: 3\n"
+
+	var cfg config
+
+	require.NoError(t, yaml.Unmarshal([]byte(in), &cfg))
+	assert.Equal(t, []string{"zeta", "alpha", "mid"}, slices.Collect(cfg.Sections.Keys()))
+
+	cfg.Sections.MoveToEnd("zeta")
+
+	out, err := yaml.Marshal(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "sections:\n    alpha: 2\n    mid: 3\n    zeta: 1\n", string(out))
+
+	require.Error(t, yaml.Unmarshal([]byte("sections: [1, 2]"), &cfg))
+}
+
+func TestOrderedMapValueField(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Sections containers.OrderedMap[string, int] `json:"sections" yaml:"sections"`
+	}
+
+	var cfg config
+
+	cfg.Sections.Set("zeta", 1)
+	cfg.Sections.Set("alpha", 2)
+
+	// marshaling by value must not lose the contents
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"sections":{"zeta":1,"alpha":2}}`, string(data))
+
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "sections:\n    zeta: 1\n    alpha: 2\n", string(out))
+
+	var decoded config
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"zeta", "alpha"}, slices.Collect(decoded.Sections.Keys()))
+
+	decoded = config{}
+
+	require.NoError(t, yaml.Unmarshal(out, &decoded))
+	assert.Equal(t, []string{"zeta", "alpha"}, slices.Collect(decoded.Sections.Keys()))
+
+	data, err = json.Marshal(config{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"sections":{}}`, string(data))
+}
@@ -0,0 +1,212 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import "iter"
+
+// PriorityQueue is a binary heap ordered by the comparison function.
+//
+// The element for which cmp reports the smallest value is popped first, so cmp.Compare gives a min-heap,
+// and a reversed comparison gives a max-heap.
+type PriorityQueue[T any] struct {
+	cmp   func(a, b T) int
+	items []*PriorityQueueHandle[T]
+}
+
+// PriorityQueueHandle references an element pushed into a PriorityQueue.
+//
+// It can be used to change the priority of the element or remove it from the queue.
+type PriorityQueueHandle[T any] struct {
+	value T
+	index int
+}
+
+// Value returns the element referenced by the handle.
+func (h *PriorityQueueHandle[T]) Value() T {
+	return h.value
+}
+
+// InQueue reports whether the element is still in the queue.
+func (h *PriorityQueueHandle[T]) InQueue() bool {
+	return h.index >= 0
+}
+
+// NewPriorityQueue creates a new empty PriorityQueue ordered by cmp.
+func NewPriorityQueue[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{cmp: cmp}
+}
+
+// Len returns the number of elements in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Push adds the element to the queue and returns a handle to it.
+func (pq *PriorityQueue[T]) Push(val T) *PriorityQueueHandle[T] {
+	h := &PriorityQueueHandle[T]{value: val, index: len(pq.items)}
+
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+
+	return h
+}
+
+// Peek returns the element with the highest priority without removing it.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		return *new(T), false
+	}
+
+	return pq.items[0].value, true
+}
+
+// Pop removes and returns the element with the highest priority.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		return *new(T), false
+	}
+
+	h := pq.items[0]
+	pq.remove(0)
+
+	return h.value, true
+}
+
+// Update replaces the element referenced by the handle and restores the heap order.
+// It returns false if the element is no longer in the queue.
+func (pq *PriorityQueue[T]) Update(h *PriorityQueueHandle[T], val T) bool {
+	if !pq.owns(h) {
+		return false
+	}
+
+	h.value = val
+	pq.fix(h.index)
+
+	return true
+}
+
+// Fix restores the heap order after the element referenced by the handle was changed in place,
+// e.g. through a pointer.
+// It returns false if the element is no longer in the queue.
+func (pq *PriorityQueue[T]) Fix(h *PriorityQueueHandle[T]) bool {
+	if !pq.owns(h) {
+		return false
+	}
+
+	pq.fix(h.index)
+
+	return true
+}
+
+// Remove removes the element referenced by the handle from the queue.
+// It returns false if the element is no longer in the queue.
+func (pq *PriorityQueue[T]) Remove(h *PriorityQueueHandle[T]) bool {
+	if !pq.owns(h) {
+		return false
+	}
+
+	pq.remove(h.index)
+
+	return true
+}
+
+// Clear removes all elements from the queue.
+func (pq *PriorityQueue[T]) Clear() {
+	for _, h := range pq.items {
+		h.index = -1
+	}
+
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// Drain returns an iterator which pops elements from the queue in priority order.
+//
+// Elements yielded before the iteration stops are removed from the queue, the rest are kept.
+// Elements pushed while iterating are yielded as well if they are next in order.
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := pq.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) owns(h *PriorityQueueHandle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+func (pq *PriorityQueue[T]) remove(i int) {
+	h := pq.items[i]
+	last := len(pq.items) - 1
+
+	if i != last {
+		pq.swap(i, last)
+	}
+
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	h.index = -1
+
+	if i != last {
+		pq.fix(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].value, pq.items[j].value) < 0
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *PriorityQueue[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 // parent
+		if !pq.less(j, i) {
+			break
+		}
+
+		pq.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the element at i0 down the heap and reports whether it was moved.
+func (pq *PriorityQueue[T]) down(i0 int) bool {
+	i, n := i0, len(pq.items)
+
+	for {
+		j := 2*i + 1 // left child
+		if j >= n || j < 0 {
+			break
+		}
+
+		if r := j + 1; r < n && pq.less(r, j) {
+			j = r
+		}
+
+		if !pq.less(j, i) {
+			break
+		}
+
+		pq.swap(i, j)
+		i = j
+	}
+
+	return i > i0
+}
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	pq := containers.NewPriorityQueue(cmp.Compare[int])
+
+	_, ok := pq.Peek()
+	require.False(t, ok)
+
+	_, ok = pq.Pop()
+	require.False(t, ok)
+
+	values := rand.Perm(100)
+
+	for _, v := range values {
+		pq.Push(v)
+	}
+
+	assert.Equal(t, 100, pq.Len())
+
+	top, ok := pq.Peek()
+	require.True(t, ok)
+	assert.Equal(t, 0, top)
+
+	val, ok := pq.Pop()
+	require.True(t, ok)
+	assert.Equal(t, 0, val)
+
+	var drained []int
+
+	for v := range pq.Drain() {
+		drained = append(drained, v)
+
+		if v == 49 {
+			break
+		}
+	}
+
+	assert.True(t, slices.IsSorted(drained))
+	assert.Len(t, drained, 49)
+	assert.Equal(t, 50, pq.Len())
+
+	pq.Clear()
+	assert.Zero(t, pq.Len())
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	t.Parallel()
+
+	type task struct {
+		name     string
+		priority int
+	}
+
+	pq := containers.NewPriorityQueue(func(a, b task) int { return cmp.Compare(b.priority, a.priority) })
+
+	a := pq.Push(task{name: "a", priority: 1})
+	b := pq.Push(task{name: "b", priority: 2})
+	c := pq.Push(task{name: "c", priority: 3})
+	d := pq.Push(task{name: "d", priority: 4})
+
+	require.True(t, pq.Update(a, task{name: "a", priority: 10}))
+	require.True(t, pq.Update(d, task{name: "d", priority: 0}))
+	require.True(t, pq.Remove(b))
+	require.False(t, pq.Remove(b))
+	assert.False(t, b.InQueue())
+
+	assert.Equal(t, "c", c.Value().name)
+
+	var order []string
+
+	for v := range pq.Drain() {
+		order = append(order, v.name)
+	}
+
+	assert.Equal(t, []string{"a", "c", "d"}, order)
+
+	assert.False(t, a.InQueue())
+	assert.False(t, pq.Update(a, task{}))
+	assert.False(t, pq.Fix(a))
+}
+
+func TestPriorityQueueFix(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		dist int
+	}
+
+	pq := containers.NewPriorityQueue(func(a, b *node) int { return cmp.Compare(a.dist, b.dist) })
+
+	nodes := make([]*node, 20)
+	handles := make([]*containers.PriorityQueueHandle[*node], len(nodes))
+
+	for i := range nodes {
+		nodes[i] = &node{dist: 100 + i}
+		handles[i] = pq.Push(nodes[i])
+	}
+
+	for i := range nodes {
+		nodes[i].dist = rand.IntN(50)
+		require.True(t, pq.Fix(handles[i]))
+	}
+
+	prev := -1
+
+	for n := range pq.Drain() {
+		assert.GreaterOrEqual(t, n.dist, prev)
+
+		prev = n.dist
+	}
+}
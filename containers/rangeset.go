@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// RangeSet is a set of points represented as a sorted list of disjoint half-open intervals.
+//
+// Overlapping and adjacent intervals are merged on insertion, so the set always holds the minimal
+// number of intervals. The zero value is an empty set ready to use.
+type RangeSet[T cmp.Ordered] struct {
+	ranges []Interval[T]
+}
+
+// NewRangeSet creates a new RangeSet containing the union of the given intervals.
+func NewRangeSet[T cmp.Ordered](intervals ...Interval[T]) *RangeSet[T] {
+	var s RangeSet[T]
+
+	for _, iv := range intervals {
+		s.Add(iv)
+	}
+
+	return &s
+}
+
+// Len returns the number of disjoint intervals in the set.
+func (s *RangeSet[T]) Len() int {
+	return len(s.ranges)
+}
+
+// All returns an iterator over the disjoint intervals in the set in ascending order.
+func (s *RangeSet[T]) All() iter.Seq[Interval[T]] {
+	return slices.Values(s.ranges)
+}
+
+// Intervals returns a copy of the disjoint intervals in the set in ascending order.
+func (s *RangeSet[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.ranges)
+}
+
+// Contains reports whether the point is in the set.
+func (s *RangeSet[T]) Contains(p T) bool {
+	// first range which ends after p
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > p })
+
+	return i < len(s.ranges) && s.ranges[i].Lo <= p
+}
+
+// ContainsInterval reports whether every point of the interval is in the set.
+func (s *RangeSet[T]) ContainsInterval(iv Interval[T]) bool {
+	if iv.Empty() {
+		return true
+	}
+
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > iv.Lo })
+
+	return i < len(s.ranges) && s.ranges[i].Lo <= iv.Lo && iv.Hi <= s.ranges[i].Hi
+}
+
+// Add adds the interval to the set, merging it with overlapping and adjacent intervals.
+func (s *RangeSet[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+
+	// ranges[i:j] overlap or touch iv
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi >= iv.Lo })
+	j := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Lo > iv.Hi })
+
+	if i < j {
+		iv.Lo = min(iv.Lo, s.ranges[i].Lo)
+		iv.Hi = max(iv.Hi, s.ranges[j-1].Hi)
+	}
+
+	s.ranges = slices.Replace(s.ranges, i, j, iv)
+}
+
+// Remove removes the interval from the set, splitting intervals if needed.
+func (s *RangeSet[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+
+	// ranges[i:j] overlap iv
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > iv.Lo })
+	j := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Lo >= iv.Hi })
+
+	if i >= j {
+		return
+	}
+
+	var remainder []Interval[T]
+
+	if first := s.ranges[i]; first.Lo < iv.Lo {
+		remainder = append(remainder, Interval[T]{Lo: first.Lo, Hi: iv.Lo})
+	}
+
+	if last := s.ranges[j-1]; last.Hi > iv.Hi {
+		remainder = append(remainder, Interval[T]{Lo: iv.Hi, Hi: last.Hi})
+	}
+
+	s.ranges = slices.Replace(s.ranges, i, j, remainder...)
+}
+
+// Union returns a new set with the points which are in s or in other.
+func (s *RangeSet[T]) Union(other *RangeSet[T]) *RangeSet[T] {
+	r := s.Clone()
+
+	for _, iv := range other.ranges {
+		r.Add(iv)
+	}
+
+	return r
+}
+
+// Subtract returns a new set with the points which are in s but not in other.
+func (s *RangeSet[T]) Subtract(other *RangeSet[T]) *RangeSet[T] {
+	r := s.Clone()
+
+	for _, iv := range other.ranges {
+		r.Remove(iv)
+	}
+
+	return r
+}
+
+// Clone returns a copy of the set.
+func (s *RangeSet[T]) Clone() *RangeSet[T] {
+	return &RangeSet[T]{ranges: slices.Clone(s.ranges)}
+}
+
+// Equal reports whether both sets contain the same points.
+func (s *RangeSet[T]) Equal(other *RangeSet[T]) bool {
+	return slices.Equal(s.ranges, other.ranges)
+}
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestRangeSet(t *testing.T) {
+	t.Parallel()
+
+	var s containers.RangeSet[int]
+
+	assert.False(t, s.Contains(0))
+
+	s.Add(iv{Lo: 10, Hi: 20})
+	s.Add(iv{Lo: 30, Hi: 40})
+	s.Add(iv{Lo: 5, Hi: 5})
+	assert.Equal(t, []iv{{Lo: 10, Hi: 20}, {Lo: 30, Hi: 40}}, s.Intervals())
+
+	s.Add(iv{Lo: 20, Hi: 25}) // adjacent
+	assert.Equal(t, []iv{{Lo: 10, Hi: 25}, {Lo: 30, Hi: 40}}, s.Intervals())
+
+	s.Add(iv{Lo: 0, Hi: 2})
+	s.Add(iv{Lo: 24, Hi: 31}) // bridges two ranges
+	assert.Equal(t, []iv{{Lo: 0, Hi: 2}, {Lo: 10, Hi: 40}}, slices.Collect(s.All()))
+
+	assert.True(t, s.Contains(10))
+	assert.True(t, s.Contains(39))
+	assert.False(t, s.Contains(40))
+	assert.False(t, s.Contains(5))
+	assert.True(t, s.ContainsInterval(iv{Lo: 12, Hi: 40}))
+	assert.False(t, s.ContainsInterval(iv{Lo: 1, Hi: 11}))
+	assert.True(t, s.ContainsInterval(iv{Lo: 7, Hi: 7}))
+
+	s.Remove(iv{Lo: 15, Hi: 20})
+	assert.Equal(t, []iv{{Lo: 0, Hi: 2}, {Lo: 10, Hi: 15}, {Lo: 20, Hi: 40}}, s.Intervals())
+
+	s.Remove(iv{Lo: 1, Hi: 25})
+	assert.Equal(t, []iv{{Lo: 0, Hi: 1}, {Lo: 25, Hi: 40}}, s.Intervals())
+
+	s.Remove(iv{Lo: 50, Hi: 60})
+	s.Remove(iv{Lo: 0, Hi: 100})
+	assert.Zero(t, s.Len())
+}
+
+func TestRangeSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	a := containers.NewRangeSet(iv{Lo: 0, Hi: 10}, iv{Lo: 20, Hi: 30})
+	b := containers.NewRangeSet(iv{Lo: 5, Hi: 25}, iv{Lo: 40, Hi: 41})
+
+	assert.Equal(t, []iv{{Lo: 0, Hi: 30}, {Lo: 40, Hi: 41}}, a.Union(b).Intervals())
+	assert.Equal(t, []iv{{Lo: 0, Hi: 5}, {Lo: 25, Hi: 30}}, a.Subtract(b).Intervals())
+	assert.Equal(t, []iv{{Lo: 10, Hi: 20}, {Lo: 40, Hi: 41}}, b.Subtract(a).Intervals())
+
+	assert.Equal(t, []iv{{Lo: 0, Hi: 10}, {Lo: 20, Hi: 30}}, a.Intervals(), "receiver must not be modified")
+
+	assert.True(t, a.Union(b).Equal(b.Union(a)))
+	assert.False(t, a.Equal(b))
+}
@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"fmt"
+	"iter"
+)
+
+// RingMode defines what Ring does when a value is pushed into a full ring.
+type RingMode int
+
+const (
+	// RingOverwrite means that the oldest value is overwritten.
+	RingOverwrite RingMode = iota
+	// RingReject means that the new value is rejected.
+	RingReject
+)
+
+// Ring is a fixed-capacity FIFO buffer.
+type Ring[T any] struct {
+	buf  []T
+	head int
+	n    int
+	mode RingMode
+}
+
+// NewRing creates a new Ring with the given capacity and full ring mode.
+//
+// It panics if capacity is not positive.
+func NewRing[T any](capacity int, mode RingMode) *Ring[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("ring capacity must be positive, got %d", capacity))
+	}
+
+	return &Ring[T]{buf: make([]T, capacity), mode: mode}
+}
+
+// Len returns the number of values in the ring.
+func (r *Ring[T]) Len() int {
+	return r.n
+}
+
+// Cap returns the capacity of the ring.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full reports whether the ring is full.
+func (r *Ring[T]) Full() bool {
+	return r.n == len(r.buf)
+}
+
+// Push appends the value to the ring.
+//
+// If the ring is full, the oldest value is overwritten in RingOverwrite mode, and the value is dropped
+// in RingReject mode. Push reports whether the value was stored.
+func (r *Ring[T]) Push(val T) bool {
+	if r.n == len(r.buf) {
+		if r.mode == RingReject {
+			return false
+		}
+
+		r.buf[r.head] = val
+		r.head = (r.head + 1) % len(r.buf)
+
+		return true
+	}
+
+	r.buf[(r.head+r.n)%len(r.buf)] = val
+	r.n++
+
+	return true
+}
+
+// Pop removes and returns the oldest value.
+func (r *Ring[T]) Pop() (T, bool) {
+	if r.n == 0 {
+		return *new(T), false
+	}
+
+	val := r.buf[r.head]
+	r.buf[r.head] = *new(T)
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+
+	return val, true
+}
+
+// At returns the i-th value, where 0 is the oldest one.
+//
+// It panics if i is out of range.
+func (r *Ring[T]) At(i int) T {
+	if i < 0 || i >= r.n {
+		panic(fmt.Sprintf("ring index %d out of range [0:%d]", i, r.n))
+	}
+
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+// Clear removes all values from the ring.
+func (r *Ring[T]) Clear() {
+	clear(r.buf)
+
+	r.head, r.n = 0, 0
+}
+
+// All returns an iterator over index-value pairs from the oldest to the newest value.
+func (r *Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < r.n; i++ {
+			if !yield(i, r.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs from the newest to the oldest value.
+func (r *Ring[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.n - 1; i >= 0; i-- {
+			if !yield(i, r.At(i)) {
+				return
+			}
+		}
+	}
+}
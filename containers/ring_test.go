@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func collectValues[T any](seq iter.Seq2[int, T]) []T {
+	var res []T
+
+	for _, v := range seq {
+		res = append(res, v)
+	}
+
+	return res
+}
+
+func TestRingOverwrite(t *testing.T) {
+	t.Parallel()
+
+	r := containers.NewRing[int](3, containers.RingOverwrite)
+
+	assert.Equal(t, 3, r.Cap())
+
+	_, ok := r.Pop()
+	require.False(t, ok)
+
+	for i := range 5 {
+		require.True(t, r.Push(i))
+	}
+
+	assert.True(t, r.Full())
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, []int{2, 3, 4}, collectValues(r.All()))
+	assert.Equal(t, []int{4, 3, 2}, collectValues(r.Backward()))
+	assert.Equal(t, 3, r.At(1))
+	assert.Panics(t, func() { r.At(3) })
+
+	val, ok := r.Pop()
+	require.True(t, ok)
+	assert.Equal(t, 2, val)
+
+	r.Push(5)
+	r.Push(6)
+
+	assert.Equal(t, []int{4, 5, 6}, collectValues(r.All()))
+
+	r.Clear()
+	assert.Zero(t, r.Len())
+	assert.Empty(t, collectValues(r.All()))
+}
+
+func TestRingReject(t *testing.T) {
+	t.Parallel()
+
+	r := containers.NewRing[string](2, containers.RingReject)
+
+	require.True(t, r.Push("a"))
+	require.True(t, r.Push("b"))
+	require.False(t, r.Push("c"))
+
+	assert.Equal(t, []string{"a", "b"}, collectValues(r.All()))
+
+	val, ok := r.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "a", val)
+
+	require.True(t, r.Push("c"))
+	assert.Equal(t, []string{"b", "c"}, collectValues(r.All()))
+
+	assert.Panics(t, func() { containers.NewRing[int](0, containers.RingReject) })
+}
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import "sync"
+
+// RWConcurrentMap is like ConcurrentMap, but guarded by sync.RWMutex, so readers don't block each other.
+type RWConcurrentMap[K comparable, V any] struct {
+	m  lockedMap[K, V]
+	mx sync.RWMutex
+}
+
+// Get returns the value for the given key.
+func (m *RWConcurrentMap[K, V]) Get(key K) (V, bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	val, ok := m.m[key]
+
+	return val, ok
+}
+
+// GetOrCreate returns the existing value for the key if present. Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *RWConcurrentMap[K, V]) GetOrCreate(key K, val V) (V, bool) {
+	return m.GetOrCall(key, func() V { return val })
+}
+
+// GetOrCall returns the existing value for the key if present. Otherwise, it calls fn, stores the result and returns it.
+// The loaded result is true if the value was loaded, false if it was created using fn.
+func (m *RWConcurrentMap[K, V]) GetOrCall(key K, fn func() V) (V, bool) {
+	if res, ok := m.Get(key); ok {
+		return res, true
+	}
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	// the key might have been stored while we were waiting for the lock
+	return m.m.getOrCall(key, fn)
+}
+
+// Set sets the value for the given key.
+func (m *RWConcurrentMap[K, V]) Set(key K, val V) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.m.set(key, val)
+}
+
+// Remove removes the value for the given key.
+func (m *RWConcurrentMap[K, V]) Remove(key K) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	delete(m.m, key)
+}
+
+// RemoveAndGet removes the value for the given key and returns it if it exists.
+func (m *RWConcurrentMap[K, V]) RemoveAndGet(key K) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return m.m.removeAndGet(key)
+}
+
+// Update atomically replaces the value for the key with the result of fn, removing the key if fn returns keep=false.
+// The function fn is called under the write lock and must not access the map.
+func (m *RWConcurrentMap[K, V]) Update(key K, fn func(val V, exists bool) (newVal V, keep bool)) (V, bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return m.m.update(key, fn)
+}
+
+// Snapshot returns a copy of the map. The copy is nil if the map is empty.
+func (m *RWConcurrentMap[K, V]) Snapshot() map[K]V {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	return m.m.snapshot()
+}
+
+// ForEach calls the given function for each key-value pair under the read lock.
+//
+// The function f must not access the map: even Get may deadlock, as sync.RWMutex forbids recursive read locking.
+// Use Snapshot to iterate without holding the lock.
+func (m *RWConcurrentMap[K, V]) ForEach(f func(K, V)) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	for k, v := range m.m {
+		f(k, v)
+	}
+}
+
+// FilterInPlace calls the given function for each key-value pair and removes the key-value pair if the function returns false.
+func (m *RWConcurrentMap[K, V]) FilterInPlace(f func(K, V) bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.m.filterInPlace(f)
+}
+
+// Len returns the number of elements in the map.
+func (m *RWConcurrentMap[K, V]) Len() int {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	return len(m.m)
+}
+
+// Clear removes all key-value pairs.
+func (m *RWConcurrentMap[K, V]) Clear() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	clear(m.m)
+}
+
+// Reset resets the underlying map.
+func (m *RWConcurrentMap[K, V]) Reset() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.m = nil
+}
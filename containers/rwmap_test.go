@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+)
+
+func TestRWConcurrentMap(t *testing.T) {
+	t.Parallel()
+
+	var m containers.RWConcurrentMap[int, string]
+
+	_, ok := m.Get(1)
+	require.False(t, ok)
+
+	m.Remove(1)
+
+	val, loaded := m.GetOrCreate(1, "one")
+	require.False(t, loaded)
+	require.Equal(t, "one", val)
+
+	val, loaded = m.GetOrCall(1, func() string { panic("must not be called") })
+	require.True(t, loaded)
+	require.Equal(t, "one", val)
+
+	m.Set(2, "two")
+	m.Set(3, "three")
+	require.Equal(t, 3, m.Len())
+
+	val, ok = m.RemoveAndGet(3)
+	require.True(t, ok)
+	require.Equal(t, "three", val)
+
+	var count int
+
+	m.ForEach(func(int, string) { count++ })
+	require.Equal(t, 2, count)
+
+	require.Equal(t, map[int]string{1: "one", 2: "two"}, m.Snapshot())
+
+	m.FilterInPlace(func(k int, _ string) bool { return k == 2 })
+	require.Equal(t, map[int]string{2: "two"}, m.Snapshot())
+
+	val, kept := m.Update(2, func(val string, exists bool) (string, bool) {
+		require.True(t, exists)
+
+		return val + "!", true
+	})
+	require.True(t, kept)
+	require.Equal(t, "two!", val)
+
+	_, kept = m.Update(2, func(string, bool) (string, bool) { return "", false })
+	require.False(t, kept)
+
+	m.Set(4, "four")
+	m.Clear()
+	require.Zero(t, m.Len())
+
+	m.Set(5, "five")
+	m.Reset()
+	require.Nil(t, m.Snapshot())
+}
+
+func TestRWConcurrentMapParallel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		m  containers.RWConcurrentMap[int, int]
+		wg sync.WaitGroup
+	)
+
+	for i := range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				m.Update(j%10, func(val int, _ bool) (int, bool) { return val + 1, true })
+
+				res, _ := m.GetOrCreate(100+i, i)
+				require.Equal(t, i, res)
+
+				m.ForEach(func(int, int) {})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for k := range 10 {
+		val, ok := m.Get(k)
+		require.True(t, ok)
+		require.Equal(t, 100, val)
+	}
+}
@@ -0,0 +1,297 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Set is a set of comparable values.
+//
+// Set has the same underlying type as the map[T]struct{} sets used across this module (see xslices.ToSet
+// and maps.Intersect), so values can be converted back and forth without copying. Methods which modify
+// the set have pointer receivers and allocate the map on demand, so the zero value is ready to use.
+// Methods which return a new set never modify their receiver or arguments.
+//
+//nolint:recvcheck
+type Set[T comparable] map[T]struct{}
+
+// NewSet creates a new set with the given items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+
+	return s
+}
+
+// CollectSet collects the values from seq into a new set.
+func CollectSet[T comparable](seq iter.Seq[T]) Set[T] {
+	s := Set[T]{}
+
+	for item := range seq {
+		s[item] = struct{}{}
+	}
+
+	return s
+}
+
+// Add adds the given items to the set.
+func (s *Set[T]) Add(items ...T) {
+	if *s == nil {
+		*s = make(Set[T], len(items))
+	}
+
+	for _, item := range items {
+		(*s)[item] = struct{}{}
+	}
+}
+
+// Remove removes the given items from the set.
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(*s, item)
+	}
+}
+
+// Clear removes all items from the set.
+func (s *Set[T]) Clear() {
+	clear(*s)
+}
+
+// Contains reports whether the item is in the set.
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// All returns an iterator over the items in the set. The iteration order is not specified.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Clone returns a copy of the set. The clone of a nil set is nil.
+func (s Set[T]) Clone() Set[T] {
+	return maps.Clone(s)
+}
+
+// Union returns a new set with the items which are in s or in any of others.
+func (s Set[T]) Union(others ...Set[T]) Set[T] {
+	r := make(Set[T], len(s))
+
+	maps.Copy(r, s)
+
+	for _, other := range others {
+		maps.Copy(r, other)
+	}
+
+	return r
+}
+
+// Intersection returns a new set with the items which are in s and in all of others.
+func (s Set[T]) Intersection(others ...Set[T]) Set[T] {
+	r := Set[T]{}
+
+outer:
+	for item := range s {
+		for _, other := range others {
+			if _, ok := other[item]; !ok {
+				continue outer
+			}
+		}
+
+		r[item] = struct{}{}
+	}
+
+	return r
+}
+
+// Difference returns a new set with the items which are in s but not in any of others.
+func (s Set[T]) Difference(others ...Set[T]) Set[T] {
+	r := Set[T]{}
+
+outer:
+	for item := range s {
+		for _, other := range others {
+			if _, ok := other[item]; ok {
+				continue outer
+			}
+		}
+
+		r[item] = struct{}{}
+	}
+
+	return r
+}
+
+// SymmetricDifference returns a new set with the items which are either in s or in other, but not in both.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	r := Set[T]{}
+
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			r[item] = struct{}{}
+		}
+	}
+
+	for item := range other {
+		if _, ok := s[item]; !ok {
+			r[item] = struct{}{}
+		}
+	}
+
+	return r
+}
+
+// IsSubset reports whether every item of s is in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for item := range s {
+		if _, ok := other[item]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset reports whether every item of other is in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint reports whether s and other have no items in common.
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	if len(s) > len(other) {
+		s, other = other, s
+	}
+
+	for item := range s {
+		if _, ok := other[item]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether s and other contain the same items. A nil set is equal to an empty set.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// MarshalJSON implements json.Marshaler. The set is encoded as a sorted array.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sortedSetItems(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of the set with the items of the array.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	if items == nil {
+		*s = nil
+
+		return nil
+	}
+
+	*s = NewSet(items...)
+
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler. The set is encoded as a sorted sequence.
+func (s Set[T]) MarshalYAML() (any, error) {
+	return sortedSetItems(s), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. It replaces the contents of the set with the items of the sequence.
+func (s *Set[T]) UnmarshalYAML(node *yaml.Node) error {
+	var items []T
+
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+
+	if items == nil {
+		*s = nil
+
+		return nil
+	}
+
+	*s = NewSet(items...)
+
+	return nil
+}
+
+// SortedSet returns the items of the set as a sorted slice.
+func SortedSet[T cmp.Ordered](s Set[T]) []T {
+	return slices.Sorted(maps.Keys(s))
+}
+
+// sortedSetItems returns the items of the set sorted by their value if T has an ordered underlying type,
+// and by their default formatting otherwise.
+func sortedSetItems[T comparable](s Set[T]) []T {
+	if s == nil {
+		return nil
+	}
+
+	items := slices.AppendSeq(make([]T, 0, len(s)), maps.Keys(s))
+
+	slices.SortFunc(items, compareAny[T])
+
+	return items
+}
+
+//nolint:exhaustive
+func compareAny[T comparable](a, b T) int {
+	va, vb := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()
+
+	switch va.Kind() {
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(va.Bool()), boolToInt(vb.Bool()))
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/maps"
+	"github.com/siderolabs/gen/xslices"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	var s containers.Set[int]
+
+	assert.False(t, s.Contains(1))
+	s.Remove(1)
+
+	s.Add(1, 2, 3)
+	s.Add(3)
+
+	assert.True(t, s.Contains(1))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []int{1, 2, 3}, containers.SortedSet(s))
+	assert.Equal(t, []int{1, 2, 3}, slices.Sorted(s.All()))
+
+	s.Remove(2)
+	assert.Equal(t, []int{1, 3}, containers.SortedSet(s))
+
+	clone := s.Clone()
+	clone.Add(4)
+	assert.Equal(t, 2, s.Len())
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+
+	assert.Nil(t, containers.Set[int](nil).Clone())
+}
+
+func TestSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	a := containers.NewSet(1, 2, 3, 4)
+	b := containers.NewSet(3, 4, 5)
+	c := containers.NewSet(4, 5, 6)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, containers.SortedSet(a.Union(b, c)))
+	assert.Equal(t, []int{4}, containers.SortedSet(a.Intersection(b, c)))
+	assert.Equal(t, []int{1, 2}, containers.SortedSet(a.Difference(b, c)))
+	assert.Equal(t, []int{1, 2, 5}, containers.SortedSet(a.SymmetricDifference(b)))
+
+	assert.Equal(t, []int{1, 2, 3, 4}, containers.SortedSet(a), "receiver must not be modified")
+
+	assert.True(t, containers.NewSet(3, 4).IsSubset(a))
+	assert.False(t, b.IsSubset(a))
+	assert.True(t, a.IsSuperset(containers.NewSet(1, 4)))
+	assert.True(t, containers.NewSet(1, 2).IsDisjoint(c))
+	assert.False(t, a.IsDisjoint(c))
+
+	assert.True(t, containers.Set[int](nil).Equal(containers.NewSet[int]()))
+	assert.True(t, a.Equal(containers.CollectSet(slices.Values([]int{4, 3, 2, 1, 1}))))
+	assert.False(t, a.Equal(b))
+}
+
+func TestSetInterop(t *testing.T) {
+	t.Parallel()
+
+	s := containers.Set[string](xslices.ToSet([]string{"a", "b", "c"}))
+
+	assert.True(t, s.Contains("b"))
+	assert.True(t, maps.Contains(s, []string{"a", "c"}))
+	assert.Equal(t, []string{"b"}, maps.Intersect(s, containers.NewSet("b", "d")))
+}
+
+func TestSetMarshal(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		Ports containers.Set[int]    `json:"ports" yaml:"ports"`
+		Names containers.Set[string] `json:"names" yaml:"names"`
+	}
+
+	in := doc{
+		Ports: containers.NewSet(443, 80, 8080),
+		Names: containers.NewSet("c", "a", "b"),
+	}
+
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ports":[80,443,8080],"names":["a","b","c"]}`, string(data))
+
+	var out doc
+
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.True(t, in.Ports.Equal(out.Ports))
+	assert.True(t, in.Names.Equal(out.Names))
+
+	data, err = yaml.Marshal(in)
+	require.NoError(t, err)
+	assert.Equal(t, "ports:\n    - 80\n    - 443\n    - 8080\nnames:\n    - a\n    - b\n    - c\n", string(data))
+
+	out = doc{}
+
+	require.NoError(t, yaml.Unmarshal(data, &out))
+	assert.True(t, in.Ports.Equal(out.Ports))
+	assert.True(t, in.Names.Equal(out.Names))
+
+	data, err = json.Marshal(containers.Set[int](nil))
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	// a non-nil empty set stays non-nil in both encodings
+	empty := containers.NewSet[int]()
+
+	data, err = json.Marshal(empty)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	var decoded containers.Set[int]
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.NotNil(t, decoded)
+	assert.Zero(t, decoded.Len())
+
+	data, err = yaml.Marshal(empty)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(data))
+
+	decoded = nil
+
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.NotNil(t, decoded)
+
+	require.Error(t, json.Unmarshal([]byte(`{"a":1}`), &out.Ports))
+}
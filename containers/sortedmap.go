@@ -0,0 +1,491 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// sortedMapDegree is the minimum degree of the B-tree backing SortedMap: every node except the root
+// holds between sortedMapDegree-1 and 2*sortedMapDegree-1 items.
+const sortedMapDegree = 16
+
+const (
+	sortedMapMaxItems = 2*sortedMapDegree - 1
+	sortedMapMinItems = sortedMapDegree - 1
+)
+
+// SortedMap is a map which keeps keys sorted, backed by a B-tree.
+//
+// Lookups, insertions and deletions are O(log n), ordered iteration starting at any key is O(log n + k).
+// SortedMap is not safe for concurrent use.
+type SortedMap[K, V any] struct {
+	cmp  func(a, b K) int
+	root *sortedMapNode[K, V]
+	len  int
+}
+
+type sortedMapItem[K, V any] struct {
+	key   K
+	value V
+}
+
+type sortedMapNode[K, V any] struct {
+	items    []sortedMapItem[K, V]
+	children []*sortedMapNode[K, V]
+}
+
+// NewSortedMap creates a new SortedMap for ordered keys.
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewSortedMapFunc creates a new SortedMap which orders keys using the comparison function.
+func NewSortedMapFunc[K, V any](cmp func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{cmp: cmp}
+}
+
+// Len returns the number of key-value pairs.
+func (m *SortedMap[K, V]) Len() int {
+	return m.len
+}
+
+// Get returns the value for the given key.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	for n := m.root; n != nil; {
+		i, found := n.find(key, m.cmp)
+		if found {
+			return n.items[i].value, true
+		}
+
+		if n.leaf() {
+			break
+		}
+
+		n = n.children[i]
+	}
+
+	return *new(V), false
+}
+
+// Set sets the value for the given key.
+func (m *SortedMap[K, V]) Set(key K, val V) {
+	item := sortedMapItem[K, V]{key: key, value: val}
+
+	if m.root == nil {
+		m.root = &sortedMapNode[K, V]{items: []sortedMapItem[K, V]{item}}
+		m.len++
+
+		return
+	}
+
+	if len(m.root.items) >= sortedMapMaxItems {
+		mid, right := m.root.split(sortedMapMaxItems / 2)
+
+		m.root = &sortedMapNode[K, V]{
+			items:    []sortedMapItem[K, V]{mid},
+			children: []*sortedMapNode[K, V]{m.root, right},
+		}
+	}
+
+	if !m.root.insert(item, m.cmp) {
+		m.len++
+	}
+}
+
+// Delete removes the value for the given key and returns it.
+func (m *SortedMap[K, V]) Delete(key K) (V, bool) {
+	item, ok := m.remove(key, sortedMapRemoveItem)
+
+	return item.value, ok
+}
+
+// DeleteRange removes all keys in the range [lo, hi) and returns the number of removed keys.
+func (m *SortedMap[K, V]) DeleteRange(lo, hi K) int {
+	var keys []K
+
+	for k := range m.Range(lo, hi) {
+		keys = append(keys, k)
+	}
+
+	for _, k := range keys {
+		m.remove(k, sortedMapRemoveItem)
+	}
+
+	return len(keys)
+}
+
+// Clear removes all key-value pairs.
+func (m *SortedMap[K, V]) Clear() {
+	m.root = nil
+	m.len = 0
+}
+
+// Min returns the smallest key and its value.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return *new(K), *new(V), false
+	}
+
+	n := m.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+
+	return n.items[0].key, n.items[0].value, true
+}
+
+// Max returns the largest key and its value.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	if m.root == nil {
+		return *new(K), *new(V), false
+	}
+
+	n := m.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+
+	item := n.items[len(n.items)-1]
+
+	return item.key, item.value, true
+}
+
+// Floor returns the largest key less than or equal to the given key and its value.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	for k, v := range m.Descend(key) {
+		return k, v, true
+	}
+
+	return *new(K), *new(V), false
+}
+
+// Ceiling returns the smallest key greater than or equal to the given key and its value.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	for k, v := range m.Ascend(key) {
+		return k, v, true
+	}
+
+	return *new(K), *new(V), false
+}
+
+// All returns an iterator over key-value pairs in ascending key order.
+//
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.ascend(sortedMapBound[K]{}, sortedMapBound[K]{}, m.cmp, yield)
+	}
+}
+
+// Backward returns an iterator over key-value pairs in descending key order.
+//
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.descend(sortedMapBound[K]{}, m.cmp, yield)
+	}
+}
+
+// Ascend returns an iterator over key-value pairs with keys greater than or equal to from, in ascending key order.
+//
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) Ascend(from K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.ascend(sortedMapBound[K]{key: from, set: true}, sortedMapBound[K]{}, m.cmp, yield)
+	}
+}
+
+// Descend returns an iterator over key-value pairs with keys less than or equal to from, in descending key order.
+//
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) Descend(from K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.descend(sortedMapBound[K]{key: from, set: true}, m.cmp, yield)
+	}
+}
+
+// Range returns an iterator over key-value pairs with keys in the range [lo, hi), in ascending key order.
+//
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.ascend(sortedMapBound[K]{key: lo, set: true}, sortedMapBound[K]{key: hi, set: true}, m.cmp, yield)
+	}
+}
+
+type sortedMapRemoveMode int
+
+const (
+	sortedMapRemoveItem sortedMapRemoveMode = iota
+	sortedMapRemoveMax
+)
+
+func (m *SortedMap[K, V]) remove(key K, mode sortedMapRemoveMode) (sortedMapItem[K, V], bool) {
+	if m.root == nil {
+		return sortedMapItem[K, V]{}, false
+	}
+
+	item, ok := m.root.remove(key, mode, m.cmp)
+
+	if len(m.root.items) == 0 {
+		if m.root.leaf() {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+
+	if ok {
+		m.len--
+	}
+
+	return item, ok
+}
+
+type sortedMapBound[K any] struct {
+	key K
+	set bool
+}
+
+func (n *sortedMapNode[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// find returns the index of the first item with key greater than or equal to the given key,
+// and whether the key is equal.
+func (n *sortedMapNode[K, V]) find(key K, cmp func(a, b K) int) (int, bool) {
+	return slices.BinarySearchFunc(n.items, key, func(item sortedMapItem[K, V], key K) int {
+		return cmp(item.key, key)
+	})
+}
+
+// split splits the node at the given index, returning the item at the index and a new node
+// with everything after it.
+func (n *sortedMapNode[K, V]) split(i int) (sortedMapItem[K, V], *sortedMapNode[K, V]) {
+	item := n.items[i]
+	next := &sortedMapNode[K, V]{}
+
+	next.items = append(next.items, n.items[i+1:]...)
+	clear(n.items[i:])
+	n.items = n.items[:i]
+
+	if !n.leaf() {
+		next.children = append(next.children, n.children[i+1:]...)
+		clear(n.children[i+1:])
+		n.children = n.children[:i+1]
+	}
+
+	return item, next
+}
+
+// maybeSplitChild splits the i-th child if it's full, and reports whether the split happened.
+func (n *sortedMapNode[K, V]) maybeSplitChild(i int) bool {
+	if len(n.children[i].items) < sortedMapMaxItems {
+		return false
+	}
+
+	item, next := n.children[i].split(sortedMapMaxItems / 2)
+
+	n.items = slices.Insert(n.items, i, item)
+	n.children = slices.Insert(n.children, i+1, next)
+
+	return true
+}
+
+// insert inserts the item into the subtree, which must not be full, and reports whether an existing
+// key was replaced.
+func (n *sortedMapNode[K, V]) insert(item sortedMapItem[K, V], cmp func(a, b K) int) bool {
+	i, found := n.find(item.key, cmp)
+	if found {
+		n.items[i].value = item.value
+
+		return true
+	}
+
+	if n.leaf() {
+		n.items = slices.Insert(n.items, i, item)
+
+		return false
+	}
+
+	if n.maybeSplitChild(i) {
+		switch c := cmp(item.key, n.items[i].key); {
+		case c > 0:
+			i++
+		case c == 0:
+			n.items[i].value = item.value
+
+			return true
+		}
+	}
+
+	return n.children[i].insert(item, cmp)
+}
+
+// remove removes an item from the subtree, making sure that every visited child has more than
+// the minimum number of items, so that removal never underflows a node.
+func (n *sortedMapNode[K, V]) remove(key K, mode sortedMapRemoveMode, cmp func(a, b K) int) (sortedMapItem[K, V], bool) {
+	var (
+		i     int
+		found bool
+	)
+
+	switch mode {
+	case sortedMapRemoveMax:
+		if n.leaf() {
+			return n.removeItemAt(len(n.items) - 1), true
+		}
+
+		i = len(n.items)
+	case sortedMapRemoveItem:
+		i, found = n.find(key, cmp)
+
+		if n.leaf() {
+			if found {
+				return n.removeItemAt(i), true
+			}
+
+			return sortedMapItem[K, V]{}, false
+		}
+	}
+
+	if len(n.children[i].items) <= sortedMapMinItems {
+		n.growChild(i)
+
+		return n.remove(key, mode, cmp)
+	}
+
+	child := n.children[i]
+
+	if found {
+		// replace the removed item with its predecessor
+		out := n.items[i]
+		n.items[i], _ = child.remove(key, sortedMapRemoveMax, cmp)
+
+		return out, true
+	}
+
+	return child.remove(key, mode, cmp)
+}
+
+// growChild makes sure the i-th child has more than the minimum number of items, either by
+// stealing an item from a sibling, or by merging it with a sibling.
+func (n *sortedMapNode[K, V]) growChild(i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > sortedMapMinItems:
+		child, left := n.children[i], n.children[i-1]
+
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.removeItemAt(len(left.items) - 1)
+
+		if !left.leaf() {
+			child.children = slices.Insert(child.children, 0, left.removeChildAt(len(left.children)-1))
+		}
+	case i < len(n.items) && len(n.children[i+1].items) > sortedMapMinItems:
+		child, right := n.children[i], n.children[i+1]
+
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.removeItemAt(0)
+
+		if !right.leaf() {
+			child.children = append(child.children, right.removeChildAt(0))
+		}
+	default:
+		if i >= len(n.items) {
+			i--
+		}
+
+		child := n.children[i]
+		item := n.removeItemAt(i)
+		merged := n.removeChildAt(i + 1)
+
+		child.items = append(child.items, item)
+		child.items = append(child.items, merged.items...)
+		child.children = append(child.children, merged.children...)
+	}
+}
+
+func (n *sortedMapNode[K, V]) removeItemAt(i int) sortedMapItem[K, V] {
+	item := n.items[i]
+	n.items = slices.Delete(n.items, i, i+1)
+
+	return item
+}
+
+func (n *sortedMapNode[K, V]) removeChildAt(i int) *sortedMapNode[K, V] {
+	child := n.children[i]
+	n.children = slices.Delete(n.children, i, i+1)
+
+	return child
+}
+
+// ascend yields items in [lo, hi) in ascending order, and reports whether the iteration should continue.
+func (n *sortedMapNode[K, V]) ascend(lo, hi sortedMapBound[K], cmp func(a, b K) int, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	i := 0
+	if lo.set {
+		i, _ = n.find(lo.key, cmp)
+	}
+
+	for ; i < len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascend(lo, hi, cmp, yield) {
+			return false
+		}
+
+		if hi.set && cmp(n.items[i].key, hi.key) >= 0 {
+			return false
+		}
+
+		if !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+
+	if !n.leaf() {
+		return n.children[len(n.items)].ascend(lo, hi, cmp, yield)
+	}
+
+	return true
+}
+
+// descend yields items less than or equal to hi in descending order, and reports whether the iteration should continue.
+func (n *sortedMapNode[K, V]) descend(hi sortedMapBound[K], cmp func(a, b K) int, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	j := len(n.items)
+
+	if hi.set {
+		idx, found := n.find(hi.key, cmp)
+
+		j = idx
+		if found {
+			j++
+		}
+	}
+
+	if !n.leaf() && !n.children[j].descend(hi, cmp, yield) {
+		return false
+	}
+
+	for i := j - 1; i >= 0; i-- {
+		if !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+
+		if !n.leaf() && !n.children[i].descend(hi, cmp, yield) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,177 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/xiter"
+)
+
+func TestSortedMap(t *testing.T) {
+	t.Parallel()
+
+	m := containers.NewSortedMap[int, string]()
+
+	_, ok := m.Get(1)
+	require.False(t, ok)
+
+	_, _, ok = m.Min()
+	require.False(t, ok)
+
+	_, _, ok = m.Max()
+	require.False(t, ok)
+
+	_, ok = m.Delete(1)
+	require.False(t, ok)
+
+	for i := range 10 {
+		m.Set(i*10, strings.Repeat("x", i))
+	}
+
+	m.Set(50, "fifty")
+
+	assert.Equal(t, 10, m.Len())
+
+	val, ok := m.Get(50)
+	require.True(t, ok)
+	assert.Equal(t, "fifty", val)
+
+	k, _, ok := m.Min()
+	require.True(t, ok)
+	assert.Equal(t, 0, k)
+
+	k, _, ok = m.Max()
+	require.True(t, ok)
+	assert.Equal(t, 90, k)
+
+	k, _, ok = m.Floor(45)
+	require.True(t, ok)
+	assert.Equal(t, 40, k)
+
+	k, _, ok = m.Floor(40)
+	require.True(t, ok)
+	assert.Equal(t, 40, k)
+
+	_, _, ok = m.Floor(-1)
+	require.False(t, ok)
+
+	k, _, ok = m.Ceiling(45)
+	require.True(t, ok)
+	assert.Equal(t, 50, k)
+
+	_, _, ok = m.Ceiling(91)
+	require.False(t, ok)
+
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, slices.Collect(xiter.Keys(m.All())))
+	assert.Equal(t, []int{90, 80, 70, 60, 50, 40, 30, 20, 10, 0}, slices.Collect(xiter.Keys(m.Backward())))
+	assert.Equal(t, []int{30, 40, 50, 60, 70, 80, 90}, slices.Collect(xiter.Keys(m.Ascend(25))))
+	assert.Equal(t, []int{20, 10, 0}, slices.Collect(xiter.Keys(m.Descend(25))))
+	assert.Equal(t, []int{20, 30, 40}, slices.Collect(xiter.Keys(m.Range(20, 50))))
+
+	assert.Equal(t, 3, m.DeleteRange(20, 50))
+	assert.Equal(t, []int{0, 10, 50, 60, 70, 80, 90}, slices.Collect(xiter.Keys(m.All())))
+
+	val, ok = m.Delete(50)
+	require.True(t, ok)
+	assert.Equal(t, "fifty", val)
+
+	m.Clear()
+	assert.Zero(t, m.Len())
+	assert.Empty(t, slices.Collect(xiter.Keys(m.All())))
+}
+
+func TestSortedMapFunc(t *testing.T) {
+	t.Parallel()
+
+	m := containers.NewSortedMapFunc[string, int](func(a, b string) int {
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	m.Set("b", 1)
+	m.Set("A", 2)
+	m.Set("a", 3)
+	m.Set("C", 4)
+
+	assert.Equal(t, []string{"A", "b", "C"}, slices.Collect(xiter.Keys(m.All())))
+
+	val, ok := m.Get("c")
+	require.True(t, ok)
+	assert.Equal(t, 4, val)
+
+	val, ok = m.Get("A")
+	require.True(t, ok)
+	assert.Equal(t, 3, val)
+}
+
+func TestSortedMapRandom(t *testing.T) {
+	t.Parallel()
+
+	m := containers.NewSortedMap[int, int]()
+	expected := map[int]int{}
+
+	for i := range 20000 {
+		key := rand.IntN(5000)
+
+		switch rand.IntN(3) {
+		case 0, 1:
+			m.Set(key, i)
+			expected[key] = i
+		case 2:
+			_, wantOK := expected[key]
+			_, ok := m.Delete(key)
+			require.Equal(t, wantOK, ok)
+
+			delete(expected, key)
+		}
+	}
+
+	require.Equal(t, len(expected), m.Len())
+
+	keys := slices.Sorted(maps.Keys(expected))
+
+	assert.Equal(t, keys, slices.Collect(xiter.Keys(m.All())))
+	assert.Equal(t, expected, maps.Collect(m.All()))
+
+	lo, hi := 1000, 3000
+	inRange := slices.DeleteFunc(slices.Clone(keys), func(k int) bool { return k < lo || k >= hi })
+
+	assert.Equal(t, inRange, slices.Collect(xiter.Keys(m.Range(lo, hi))))
+
+	descending := slices.DeleteFunc(slices.Clone(keys), func(k int) bool { return k > hi })
+	slices.Reverse(descending)
+
+	assert.Equal(t, descending, slices.Collect(xiter.Keys(m.Descend(hi))))
+
+	var firstTwo []int
+
+	for k := range m.Ascend(lo) {
+		firstTwo = append(firstTwo, k)
+
+		if len(firstTwo) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, inRange[:2], firstTwo)
+
+	assert.Equal(t, len(inRange), m.DeleteRange(lo, hi))
+	assert.Equal(t, len(keys)-len(inRange), m.Len())
+
+	for _, k := range keys {
+		m.Delete(k)
+	}
+
+	assert.Zero(t, m.Len())
+}
@@ -4,13 +4,27 @@
 
 package containers
 
-import "sync"
+import (
+	"iter"
+	"sync"
+)
 
 // SyncMap is a wrapper around sync.Map that provides type safety.
 type SyncMap[K comparable, V any] struct {
 	m sync.Map
 }
 
+// NewSyncMapFrom creates a new SyncMap filled with the key-value pairs of the given map.
+func NewSyncMapFrom[K comparable, V any](m map[K]V) *SyncMap[K, V] {
+	var res SyncMap[K, V]
+
+	for k, v := range m {
+		res.m.Store(k, v)
+	}
+
+	return &res
+}
+
 // Load returns the value stored in the map for a key, or zero value if no value is
 // present.
 func (m *SyncMap[K, V]) Load(key K) (value V, ok bool) {
@@ -78,6 +92,75 @@ func (m *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
 	return castOrZero[V](val), loaded
 }
 
+// LoadOrCompute returns the existing value for the key if present.
+// Otherwise, it calls fn, stores and returns the result.
+// The loaded result is true if the value was loaded, false if stored.
+//
+// If several goroutines call LoadOrCompute for the same missing key concurrently, fn might be called more than once,
+// but only one result is stored and returned to all callers.
+func (m *SyncMap[K, V]) LoadOrCompute(key K, fn func() V) (actual V, loaded bool) {
+	if val, ok := m.m.Load(key); ok {
+		return castOrZero[V](val), true
+	}
+
+	val, loaded := m.m.LoadOrStore(key, fn())
+
+	return castOrZero[V](val), loaded
+}
+
+// Clear deletes all the entries.
+func (m *SyncMap[K, V]) Clear() {
+	m.m.Clear()
+}
+
+// Len returns the number of entries in the map.
+//
+// It iterates over the whole map, so it's O(n), and the result might be stale if the map is modified concurrently.
+func (m *SyncMap[K, V]) Len() int {
+	n := 0
+
+	m.m.Range(func(_, _ any) bool {
+		n++
+
+		return true
+	})
+
+	return n
+}
+
+// All returns an iterator over key-value pairs in the map.
+//
+// It has the same consistency guarantees as Range.
+func (m *SyncMap[K, V]) All() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// Keys returns an iterator over keys in the map.
+//
+// It has the same consistency guarantees as Range.
+func (m *SyncMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.m.Range(func(key, _ any) bool {
+			return yield(castOrZero[K](key))
+		})
+	}
+}
+
+// Values returns an iterator over values in the map.
+//
+// It has the same consistency guarantees as Range.
+func (m *SyncMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.m.Range(func(_, value any) bool {
+			return yield(castOrZero[V](value))
+		})
+	}
+}
+
+// castOrZero converts the value stored in sync.Map back to T.
+//
+// The only way to get untyped nil from sync.Map is to store a nil interface value, which is only possible
+// if T is an interface type, so returning the zero value of T gives back exactly what was stored.
 func castOrZero[T any](val any) T {
 	if val == nil {
 		var zero T
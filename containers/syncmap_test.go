@@ -5,6 +5,8 @@
 package containers_test
 
 import (
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -118,4 +120,36 @@ func TestSyncMapPtr(t *testing.T) {
 	assert.Zero(t, previous)
 }
 
+func TestSyncMapExtensions(t *testing.T) {
+	t.Parallel()
+
+	m := containers.NewSyncMapFrom(map[string]int{"a": 1, "b": 2})
+
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(m.All()))
+	assert.Equal(t, []string{"a", "b"}, slices.Sorted(m.Keys()))
+	assert.Equal(t, []int{1, 2}, slices.Sorted(m.Values()))
+
+	for range m.All() {
+		break
+	}
+
+	actual, loaded := m.LoadOrCompute("a", func() int { panic("must not be called") })
+	assert.True(t, loaded)
+	assert.Equal(t, 1, actual)
+
+	actual, loaded = m.LoadOrCompute("c", func() int { return 3 })
+	assert.False(t, loaded)
+	assert.Equal(t, 3, actual)
+	assert.Equal(t, 3, m.Len())
+
+	m.Clear()
+	assert.Zero(t, m.Len())
+
+	var empty containers.SyncMap[int, int]
+
+	assert.Zero(t, empty.Len())
+	assert.Empty(t, slices.Collect(empty.Keys()))
+}
+
 func ptrTo[V any](v V) *V { return &v }
@@ -0,0 +1,276 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Trie is a radix tree mapping string keys to values.
+//
+// Besides exact lookups, it answers longest prefix match and "all keys with a prefix" queries,
+// which makes it a good fit for path-like keys. Iteration order is lexicographic by bytes.
+// The zero value is an empty trie ready to use.
+type Trie[V any] struct {
+	root trieNode[V]
+	len  int
+}
+
+type trieNode[V any] struct {
+	value    V
+	prefix   string
+	children []*trieNode[V] // sorted by the first byte of prefix
+	hasValue bool
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie[V]) Len() int {
+	return t.len
+}
+
+// Insert sets the value for the given key. It reports whether the key was already present.
+func (t *Trie[V]) Insert(key string, val V) bool {
+	n, search := &t.root, key
+
+	for {
+		if search == "" {
+			replaced := n.hasValue
+			n.value, n.hasValue = val, true
+
+			if !replaced {
+				t.len++
+			}
+
+			return replaced
+		}
+
+		idx, found := n.find(search[0])
+		if !found {
+			n.children = slices.Insert(n.children, idx, &trieNode[V]{prefix: search, value: val, hasValue: true})
+			t.len++
+
+			return false
+		}
+
+		child := n.children[idx]
+
+		common := commonPrefixLen(search, child.prefix)
+		if common == len(child.prefix) {
+			n, search = child, search[common:]
+
+			continue
+		}
+
+		// split the edge at the common prefix
+		split := &trieNode[V]{prefix: search[:common]}
+		n.children[idx] = split
+
+		child.prefix = child.prefix[common:]
+		split.children = []*trieNode[V]{child}
+
+		if search = search[common:]; search == "" {
+			split.value, split.hasValue = val, true
+		} else {
+			split.insertChild(&trieNode[V]{prefix: search, value: val, hasValue: true})
+		}
+
+		t.len++
+
+		return false
+	}
+}
+
+// Get returns the value for the given key.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n, search := &t.root, key
+
+	for search != "" {
+		idx, found := n.find(search[0])
+		if !found || !strings.HasPrefix(search, n.children[idx].prefix) {
+			return *new(V), false
+		}
+
+		n = n.children[idx]
+		search = search[len(n.prefix):]
+	}
+
+	return n.value, n.hasValue
+}
+
+// Delete removes the given key. It reports whether the key was present.
+func (t *Trie[V]) Delete(key string) bool {
+	var (
+		parent *trieNode[V]
+		idx    int
+	)
+
+	n, search := &t.root, key
+
+	for search != "" {
+		var found bool
+
+		idx, found = n.find(search[0])
+		if !found || !strings.HasPrefix(search, n.children[idx].prefix) {
+			return false
+		}
+
+		parent, n = n, n.children[idx]
+		search = search[len(n.prefix):]
+	}
+
+	if !n.hasValue {
+		return false
+	}
+
+	n.value, n.hasValue = *new(V), false
+	t.len--
+
+	if parent == nil {
+		return true
+	}
+
+	switch len(n.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, idx, idx+1)
+
+		if parent != &t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+
+	return true
+}
+
+// LongestPrefix returns the longest key in the trie which is a prefix of s, and its value.
+func (t *Trie[V]) LongestPrefix(s string) (string, V, bool) {
+	var (
+		matchedKey string
+		matched    *trieNode[V]
+	)
+
+	n, search := &t.root, s
+
+	for {
+		if n.hasValue {
+			matched, matchedKey = n, s[:len(s)-len(search)]
+		}
+
+		if search == "" {
+			break
+		}
+
+		idx, found := n.find(search[0])
+		if !found || !strings.HasPrefix(search, n.children[idx].prefix) {
+			break
+		}
+
+		n = n.children[idx]
+		search = search[len(n.prefix):]
+	}
+
+	if matched == nil {
+		return "", *new(V), false
+	}
+
+	return matchedKey, matched.value, true
+}
+
+// WalkPrefix returns an iterator over the keys which start with the given prefix, and their values,
+// in lexicographic order.
+//
+// The trie must not be modified during iteration.
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n, search, key := &t.root, prefix, ""
+
+		for search != "" {
+			idx, found := n.find(search[0])
+			if !found {
+				return
+			}
+
+			n = n.children[idx]
+
+			switch {
+			case strings.HasPrefix(search, n.prefix):
+				search = search[len(n.prefix):]
+			case strings.HasPrefix(n.prefix, search):
+				// prefix ends in the middle of the edge
+				search = ""
+			default:
+				return
+			}
+
+			key += n.prefix
+		}
+
+		n.walk(key, yield)
+	}
+}
+
+// All returns an iterator over all keys and values in lexicographic order.
+//
+// The trie must not be modified during iteration.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WalkPrefix("")
+}
+
+// Clear removes all keys from the trie.
+func (t *Trie[V]) Clear() {
+	t.root = trieNode[V]{}
+	t.len = 0
+}
+
+func (n *trieNode[V]) find(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(child *trieNode[V], b byte) int {
+		return cmp.Compare(child.prefix[0], b)
+	})
+}
+
+func (n *trieNode[V]) insertChild(child *trieNode[V]) {
+	idx, _ := n.find(child.prefix[0])
+
+	n.children = slices.Insert(n.children, idx, child)
+}
+
+// mergeChild merges the only child of the node into it.
+func (n *trieNode[V]) mergeChild() {
+	child := n.children[0]
+
+	n.prefix += child.prefix
+	n.value, n.hasValue = child.value, child.hasValue
+	n.children = child.children
+}
+
+func (n *trieNode[V]) walk(key string, yield func(string, V) bool) bool {
+	if n.hasValue && !yield(key, n.value) {
+		return false
+	}
+
+	for _, child := range n.children {
+		if !child.walk(key+child.prefix, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return n
+}
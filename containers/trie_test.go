@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containers_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/containers"
+	"github.com/siderolabs/gen/xiter"
+)
+
+func TestTrie(t *testing.T) {
+	t.Parallel()
+
+	var trie containers.Trie[int]
+
+	_, ok := trie.Get("")
+	require.False(t, ok)
+
+	_, _, ok = trie.LongestPrefix("/system")
+	require.False(t, ok)
+
+	for i, key := range []string{
+		"/system/state/config",
+		"/system/state",
+		"/system/secrets",
+		"/system",
+		"/var/log",
+		"/var/lib",
+	} {
+		require.False(t, trie.Insert(key, i))
+	}
+
+	require.True(t, trie.Insert("/system", 42))
+
+	assert.Equal(t, 6, trie.Len())
+
+	val, ok := trie.Get("/system")
+	require.True(t, ok)
+	assert.Equal(t, 42, val)
+
+	_, ok = trie.Get("/sys")
+	require.False(t, ok)
+
+	_, ok = trie.Get("/var")
+	require.False(t, ok)
+
+	key, val, ok := trie.LongestPrefix("/system/state/config/extra")
+	require.True(t, ok)
+	assert.Equal(t, "/system/state/config", key)
+	assert.Equal(t, 0, val)
+
+	key, _, ok = trie.LongestPrefix("/system/stat")
+	require.True(t, ok)
+	assert.Equal(t, "/system", key)
+
+	_, _, ok = trie.LongestPrefix("/var/li")
+	require.False(t, ok)
+
+	assert.Equal(t,
+		[]string{"/system", "/system/secrets", "/system/state", "/system/state/config"},
+		slices.Collect(xiter.Keys(trie.WalkPrefix("/system"))),
+	)
+	assert.Equal(t,
+		[]string{"/system/secrets", "/system/state", "/system/state/config"},
+		slices.Collect(xiter.Keys(trie.WalkPrefix("/system/s"))),
+	)
+	assert.Equal(t,
+		[]string{"/var/lib", "/var/log"},
+		slices.Collect(xiter.Keys(trie.WalkPrefix("/va"))),
+	)
+	assert.Empty(t, slices.Collect(xiter.Keys(trie.WalkPrefix("/x"))))
+	assert.Empty(t, slices.Collect(xiter.Keys(trie.WalkPrefix("/system/z"))))
+
+	require.False(t, trie.Delete("/sys"))
+	require.True(t, trie.Delete("/system"))
+	require.False(t, trie.Delete("/system"))
+	require.True(t, trie.Delete("/system/state"))
+	require.True(t, trie.Delete("/var/log"))
+
+	assert.Equal(t,
+		map[string]int{"/system/state/config": 0, "/system/secrets": 2, "/var/lib": 5},
+		maps.Collect(trie.All()),
+	)
+
+	require.False(t, trie.Insert("", -1))
+
+	val, ok = trie.Get("")
+	require.True(t, ok)
+	assert.Equal(t, -1, val)
+
+	key, _, ok = trie.LongestPrefix("/nothing")
+	require.True(t, ok)
+	assert.Equal(t, "", key)
+
+	trie.Clear()
+	assert.Zero(t, trie.Len())
+	assert.Empty(t, slices.Collect(xiter.Keys(trie.All())))
+}
+
+func TestTrieRandom(t *testing.T) {
+	t.Parallel()
+
+	var trie containers.Trie[int]
+
+	expected := map[string]int{}
+
+	randomKey := func() string {
+		var sb strings.Builder
+
+		for range rand.IntN(8) {
+			sb.WriteByte("abc/"[rand.IntN(4)])
+		}
+
+		return sb.String()
+	}
+
+	for i := range 10000 {
+		key := randomKey()
+
+		if rand.IntN(3) == 0 {
+			_, wantOK := expected[key]
+			require.Equal(t, wantOK, trie.Delete(key))
+
+			delete(expected, key)
+
+			continue
+		}
+
+		_, wantOK := expected[key]
+		require.Equal(t, wantOK, trie.Insert(key, i))
+
+		expected[key] = i
+	}
+
+	require.Equal(t, len(expected), trie.Len())
+	assert.Equal(t, slices.Sorted(maps.Keys(expected)), slices.Collect(xiter.Keys(trie.All())))
+
+	for range 100 {
+		prefix := randomKey()
+
+		var want []string
+
+		for k := range expected {
+			if strings.HasPrefix(k, prefix) {
+				want = append(want, k)
+			}
+		}
+
+		slices.Sort(want)
+
+		assert.Equal(t, want, slices.Collect(xiter.Keys(trie.WalkPrefix(prefix))))
+
+		longest, ok := "", false
+
+		for k := range expected {
+			if strings.HasPrefix(prefix, k) && len(k) >= len(longest) {
+				longest, ok = k, true
+			}
+		}
+
+		key, _, found := trie.LongestPrefix(prefix)
+		require.Equal(t, ok, found)
+		assert.Equal(t, longest, key)
+	}
+}
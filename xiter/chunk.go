@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// maxPrealloc caps the buffer capacity allocated up front from a caller-provided size,
+// so a large size doesn't allocate more than the sequence needs.
+const maxPrealloc = 64
+
+// Chunk returns an iterator over consecutive chunks of up to n elements of seq.
+// All chunks except the last one have exactly n elements. Each chunk is a new slice.
+//
+// It panics if n is not positive.
+func Chunk[V any](n int, seq iter.Seq[V]) iter.Seq[[]V] {
+	if n <= 0 {
+		panic(fmt.Sprintf("chunk size must be positive, got %d", n))
+	}
+
+	return func(yield func([]V) bool) {
+		chunk := make([]V, 0, min(n, maxPrealloc))
+
+		for v := range seq {
+			chunk = append(chunk, v)
+
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+
+				chunk = make([]V, 0, min(n, maxPrealloc))
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// ChunkBy returns an iterator over consecutive chunks of seq, starting a new chunk whenever
+// boundary(prev, cur) returns true for two adjacent elements. Each chunk is a new slice.
+func ChunkBy[V any](boundary func(prev, cur V) bool, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var chunk []V
+
+		for v := range seq {
+			if len(chunk) > 0 && boundary(chunk[len(chunk)-1], v) {
+				if !yield(chunk) {
+					return
+				}
+
+				chunk = nil
+			}
+
+			chunk = append(chunk, v)
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window returns an iterator over all sliding windows of n consecutive elements of seq.
+// If seq has fewer than n elements, nothing is yielded. Each window is a new slice.
+//
+// It panics if n is not positive.
+func Window[V any](n int, seq iter.Seq[V]) iter.Seq[[]V] {
+	windows := WindowReuse(n, seq)
+
+	return func(yield func([]V) bool) {
+		for window := range windows {
+			if !yield(slices.Clone(window)) {
+				return
+			}
+		}
+	}
+}
+
+// WindowReuse is like Window, but yields the same slice for every window, avoiding allocations.
+// The slice is only valid until the next iteration, so it must be copied to be retained.
+//
+// It panics if n is not positive.
+func WindowReuse[V any](n int, seq iter.Seq[V]) iter.Seq[[]V] {
+	if n <= 0 {
+		panic(fmt.Sprintf("window size must be positive, got %d", n))
+	}
+
+	return func(yield func([]V) bool) {
+		window := make([]V, 0, min(n, maxPrealloc))
+
+		for v := range seq {
+			if len(window) == n {
+				copy(window, window[1:])
+				window[n-1] = v
+			} else {
+				window = append(window, v)
+			}
+
+			if len(window) == n && !yield(window) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleChunk() {
+	for chunk := range xiter.Chunk(3, slices.Values([]int{1, 2, 3, 4, 5, 6, 7})) {
+		fmt.Println(chunk)
+	}
+
+	// Output:
+	// [1 2 3]
+	// [4 5 6]
+	// [7]
+}
+
+func ExampleChunkBy() {
+	// split into runs of ascending numbers
+	for chunk := range xiter.ChunkBy(func(prev, cur int) bool { return cur < prev }, slices.Values([]int{1, 2, 5, 3, 4, 0})) {
+		fmt.Println(chunk)
+	}
+
+	// Output:
+	// [1 2 5]
+	// [3 4]
+	// [0]
+}
+
+func ExampleWindow() {
+	for window := range xiter.Window(3, slices.Values([]int{1, 2, 3, 4, 5})) {
+		fmt.Println(window)
+	}
+
+	// Output:
+	// [1 2 3]
+	// [2 3 4]
+	// [3 4 5]
+}
+
+func ExampleWindowReuse() {
+	// rolling sum
+	for window := range xiter.WindowReuse(2, slices.Values([]int{1, 2, 3, 4})) {
+		fmt.Println(window[0] + window[1])
+	}
+
+	// Output:
+	// 3
+	// 5
+	// 7
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, slices.Collect(xiter.Chunk(2, xiter.Empty[int])))
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}}, slices.Collect(xiter.Chunk(2, slices.Values([]int{0, 1, 2, 3}))))
+	assert.Panics(t, func() { xiter.Chunk(0, xiter.Empty[int]) })
+	assert.Equal(t, [][]int{{0, 1, 2}}, slices.Collect(xiter.Chunk(math.MaxInt, slices.Values([]int{0, 1, 2}))))
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for chunk := range xiter.Chunk(3, trackedSeq(100, &pulled, &finished)) {
+		if chunk[0] == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, 6, pulled)
+	assert.True(t, finished)
+}
+
+func TestChunkBy(t *testing.T) {
+	t.Parallel()
+
+	never := func(int, int) bool { return false }
+	always := func(int, int) bool { return true }
+
+	assert.Empty(t, slices.Collect(xiter.ChunkBy(never, xiter.Empty[int])))
+	assert.Equal(t, [][]int{{0, 1, 2}}, slices.Collect(xiter.ChunkBy(never, slices.Values([]int{0, 1, 2}))))
+	assert.Equal(t, [][]int{{0}, {1}, {2}}, slices.Collect(xiter.ChunkBy(always, slices.Values([]int{0, 1, 2}))))
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for range xiter.ChunkBy(always, trackedSeq(100, &pulled, &finished)) {
+		break
+	}
+
+	assert.Equal(t, 2, pulled, "the first chunk is only known to be complete after the next element")
+	assert.True(t, finished)
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, slices.Collect(xiter.Window(3, slices.Values([]int{1, 2}))))
+	assert.Equal(t, [][]int{{1}, {2}}, slices.Collect(xiter.Window(1, slices.Values([]int{1, 2}))))
+	assert.Panics(t, func() { xiter.Window(-1, xiter.Empty[int]) })
+	assert.Empty(t, slices.Collect(xiter.Window(math.MaxInt, slices.Values([]int{1, 2}))))
+
+	// windows larger than the preallocated buffer
+	values := make([]int, 101)
+
+	for i := range values {
+		values[i] = i
+	}
+
+	windows := slices.Collect(xiter.Window(100, slices.Values(values)))
+	assert.Equal(t, [][]int{values[:100], values[1:]}, windows)
+
+	var reused [][]int
+
+	for window := range xiter.WindowReuse(2, slices.Values([]int{1, 2, 3})) {
+		reused = append(reused, window)
+	}
+
+	assert.Len(t, reused, 2)
+	assert.Same(t, &reused[0][0], &reused[1][0], "buffer must be reused")
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for window := range xiter.Window(4, trackedSeq(100, &pulled, &finished)) {
+		if window[0] == 1 {
+			break
+		}
+	}
+
+	assert.Equal(t, 5, pulled)
+	assert.True(t, finished)
+}
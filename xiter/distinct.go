@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import "iter"
+
+// Distinct returns an iterator over the elements of seq, skipping elements which were already yielded.
+//
+// All yielded elements are kept in memory, see containers.DistinctByRecent and containers.DistinctByApprox for bounded alternatives.
+func Distinct[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return DistinctBy(func(v V) V { return v }, seq)
+}
+
+// DistinctBy returns an iterator over the elements of seq, skipping elements with a key which was already yielded.
+//
+// All yielded keys are kept in memory, see containers.DistinctByRecent and containers.DistinctByApprox for bounded alternatives.
+func DistinctBy[K comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		seen := map[K]struct{}{}
+
+		for v := range seq {
+			k := key(v)
+
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Compact returns an iterator over the elements of seq, skipping elements equal to the preceding one.
+func Compact[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var (
+			prev    V
+			hasPrev bool
+		)
+
+		for v := range seq {
+			if hasPrev && v == prev {
+				continue
+			}
+
+			prev, hasPrev = v, true
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
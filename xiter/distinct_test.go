@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleDistinct() {
+	fmt.Println(slices.Collect(xiter.Distinct(slices.Values([]int{1, 2, 1, 3, 2}))))
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleDistinctBy() {
+	fmt.Println(slices.Collect(xiter.DistinctBy(strings.ToLower, slices.Values([]string{"a", "B", "A", "b", "c"}))))
+
+	// Output:
+	// [a B c]
+}
+
+func ExampleCompact() {
+	fmt.Println(slices.Collect(xiter.Compact(slices.Values([]int{1, 1, 2, 2, 2, 1, 3, 3}))))
+
+	// Output:
+	// [1 2 1 3]
+}
+
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, slices.Collect(xiter.Distinct(xiter.Empty[int])))
+	assert.Empty(t, slices.Collect(xiter.Compact(xiter.Empty[int])))
+	assert.Equal(t, []int{0}, slices.Collect(xiter.Compact(slices.Values([]int{0, 0}))))
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for v := range xiter.Distinct(xiter.Concat(slices.Values([]int{0, 0, 0}), trackedSeq(100, &pulled, &finished))) {
+		if v == 1 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, pulled)
+	assert.True(t, finished)
+
+	pulled = 0
+
+	for range xiter.Compact(trackedSeq(100, &pulled, &finished)) {
+		break
+	}
+
+	assert.Equal(t, 1, pulled)
+}
@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"context"
+	"iter"
+)
+
+// The helpers below work with fallible sequences of iter.Seq2[V, error] type: each element is either a value
+// with a nil error, or a zero value with a non-nil error. A sequence may continue after an error, it's up to the
+// consumer to decide whether to stop; CollectErr and TryReduce stop at the first error.
+
+// MapErr returns an iterator over f applied to the values in seq.
+// Errors from seq are passed through without calling f, errors returned by f are yielded with a zero value.
+func MapErr[In, Out any](f func(In) (Out, error), seq iter.Seq2[In, error]) iter.Seq2[Out, error] {
+	return func(yield func(Out, error) bool) {
+		for in, err := range seq {
+			if err != nil {
+				if !yield(*new(Out), err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(f(in)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterErr returns an iterator over the values in seq for which f returns true.
+// Errors from seq are passed through without calling f, errors returned by f are yielded with a zero value.
+func FilterErr[V any](f func(V) (bool, error), seq iter.Seq2[V, error]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for v, err := range seq {
+			if err != nil {
+				if !yield(*new(V), err) {
+					return
+				}
+
+				continue
+			}
+
+			ok, err := f(v)
+
+			switch {
+			case err != nil:
+				if !yield(*new(V), err) {
+					return
+				}
+			case ok:
+				if !yield(v, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CollectErr collects the values from seq into a slice, stopping at the first error.
+// On error, it returns the values collected before the error.
+func CollectErr[V any](seq iter.Seq2[V, error]) ([]V, error) {
+	var result []V
+
+	for v, err := range seq {
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// TryReduce applies f to the values in seq, starting with the initial value, and stops at the first error
+// either from seq or from f. On error, it returns the result accumulated before the error.
+func TryReduce[V, R any](f func(R, V) (R, error), sum R, seq iter.Seq2[V, error]) (R, error) {
+	for v, err := range seq {
+		if err != nil {
+			return sum, err
+		}
+
+		next, err := f(sum, v)
+		if err != nil {
+			return sum, err
+		}
+
+		sum = next
+	}
+
+	return sum, nil
+}
+
+// WithContext returns an iterator over seq which ends when ctx is canceled, yielding ctx.Err() as the last element.
+//
+// The context is checked before pulling each element, so a source blocked on producing an element
+// should watch the context on its own.
+func WithContext[V any](ctx context.Context, seq iter.Seq2[V, error]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		if err := ctx.Err(); err != nil {
+			yield(*new(V), err)
+
+			return
+		}
+
+		for v, err := range seq {
+			if !yield(v, err) {
+				return
+			}
+
+			if err = ctx.Err(); err != nil {
+				yield(*new(V), err)
+
+				return
+			}
+		}
+	}
+}
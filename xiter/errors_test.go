@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+// parsed returns a fallible sequence of strings parsed as integers.
+func parsed(values ...string) iter.Seq2[int, error] {
+	return xiter.ToSeq2(strconv.Atoi, slices.Values(values))
+}
+
+func ExampleMapErr() {
+	doubled := xiter.MapErr(func(v int) (int, error) { return v * 2, nil }, parsed("1", "x", "3"))
+
+	for v, err := range doubled {
+		fmt.Println(v, err)
+	}
+
+	// Output:
+	// 2 <nil>
+	// 0 strconv.Atoi: parsing "x": invalid syntax
+	// 6 <nil>
+}
+
+func ExampleFilterErr() {
+	odd := xiter.FilterErr(func(v int) (bool, error) { return v%2 == 1, nil }, parsed("1", "2", "3"))
+
+	fmt.Println(xiter.CollectErr(odd))
+
+	// Output:
+	// [1 3] <nil>
+}
+
+func ExampleCollectErr() {
+	fmt.Println(xiter.CollectErr(parsed("1", "2", "3")))
+	fmt.Println(xiter.CollectErr(parsed("1", "x", "3")))
+
+	// Output:
+	// [1 2 3] <nil>
+	// [1] strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleTryReduce() {
+	sum := func(sum, v int) (int, error) { return sum + v, nil }
+
+	fmt.Println(xiter.TryReduce(sum, 0, parsed("1", "2", "3")))
+	fmt.Println(xiter.TryReduce(sum, 0, parsed("1", "2", "x")))
+
+	// Output:
+	// 6 <nil>
+	// 3 strconv.Atoi: parsing "x": invalid syntax
+}
+
+func TestMapErr(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+
+	values, err := xiter.CollectErr(xiter.MapErr(func(v int) (string, error) {
+		if v%2 == 1 {
+			return "", errOdd
+		}
+
+		return strconv.Itoa(v * 10), nil
+	}, parsed("2", "4", "5", "6")))
+
+	require.ErrorIs(t, err, errOdd)
+	assert.Equal(t, []string{"20", "40"}, values)
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	_, err = xiter.CollectErr(xiter.MapErr(func(v int) (int, error) {
+		if v == 2 {
+			return 0, errOdd
+		}
+
+		return v, nil
+	}, xiter.ToSeq2(func(v int) (int, error) { return v, nil }, trackedSeq(100, &pulled, &finished))))
+
+	require.ErrorIs(t, err, errOdd)
+	assert.Equal(t, 3, pulled)
+	assert.True(t, finished)
+}
+
+func TestFilterErr(t *testing.T) {
+	t.Parallel()
+
+	errFilter := errors.New("filter")
+
+	var results []string
+
+	for v, err := range xiter.FilterErr(func(v int) (bool, error) {
+		if v == 3 {
+			return false, errFilter
+		}
+
+		return v > 1, nil
+	}, parsed("1", "2", "x", "3", "4")) {
+		if err != nil {
+			results = append(results, "error")
+
+			continue
+		}
+
+		results = append(results, strconv.Itoa(v))
+	}
+
+	assert.Equal(t, []string{"2", "error", "error", "4"}, results)
+}
+
+func TestTryReduce(t *testing.T) {
+	t.Parallel()
+
+	errTooBig := errors.New("too big")
+
+	sum, err := xiter.TryReduce(func(sum, v int) (int, error) {
+		if sum+v > 5 {
+			return sum, errTooBig
+		}
+
+		return sum + v, nil
+	}, 0, parsed("1", "2", "3", "4"))
+
+	require.ErrorIs(t, err, errTooBig)
+	assert.Equal(t, 3, sum)
+}
+
+func TestWithContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	source := xiter.ToSeq2(func(v int) (int, error) { return v, nil }, trackedSeq(100, &pulled, &finished))
+
+	var values []int
+
+	for v, err := range xiter.WithContext(ctx, source) {
+		if err != nil {
+			require.ErrorIs(t, err, context.Canceled)
+
+			continue
+		}
+
+		values = append(values, v)
+
+		if v == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, values)
+	assert.Equal(t, 3, pulled)
+	assert.True(t, finished)
+
+	values, err := xiter.CollectErr(xiter.WithContext(ctx, parsed("1")))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, values)
+
+	values, err = xiter.CollectErr(xiter.WithContext(t.Context(), parsed("1", "2")))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+}
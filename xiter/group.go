@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import "iter"
+
+// GroupBy collects the elements of seq into a map of slices keyed by the result of key.
+// Elements within each group keep their order in seq.
+func GroupBy[K comparable, V any](key func(V) K, seq iter.Seq[V]) map[K][]V {
+	groups := map[K][]V{}
+
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+
+	return groups
+}
+
+// GroupByConsecutive returns an iterator over the runs of consecutive elements of seq with the same key.
+//
+// Unlike GroupBy, it doesn't collect the whole sequence, so it works on streams already ordered by key;
+// a key which appears in several runs is yielded several times. Each group is a new slice.
+func GroupByConsecutive[K comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		var (
+			group    []V
+			groupKey K
+		)
+
+		for v := range seq {
+			k := key(v)
+
+			if len(group) > 0 && k != groupKey {
+				if !yield(groupKey, group) {
+					return
+				}
+
+				group = nil
+			}
+
+			group = append(group, v)
+			groupKey = k
+		}
+
+		if len(group) > 0 {
+			yield(groupKey, group)
+		}
+	}
+}
+
+// CountBy counts the elements of seq by the result of key.
+func CountBy[K comparable, V any](key func(V) K, seq iter.Seq[V]) map[K]int {
+	counts := map[K]int{}
+
+	for v := range seq {
+		counts[key(v)]++
+	}
+
+	return counts
+}
+
+// Partition splits the elements of seq into those for which pred returns true and the rest, keeping their order.
+func Partition[V any](pred func(V) bool, seq iter.Seq[V]) (yes, no []V) {
+	for v := range seq {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+
+	return yes, no
+}
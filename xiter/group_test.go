@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleGroupBy() {
+	words := slices.Values([]string{"apple", "avocado", "banana", "blueberry", "cherry"})
+
+	groups := xiter.GroupBy(func(s string) byte { return s[0] }, words)
+
+	fmt.Println(groups['a'], groups['b'], groups['c'])
+
+	// Output:
+	// [apple avocado] [banana blueberry] [cherry]
+}
+
+func ExampleGroupByConsecutive() {
+	lines := slices.Values([]string{"INFO start", "INFO ready", "WARN slow", "INFO done"})
+
+	for level, group := range xiter.GroupByConsecutive(func(s string) string { return strings.Fields(s)[0] }, lines) {
+		fmt.Println(level, len(group))
+	}
+
+	// Output:
+	// INFO 2
+	// WARN 1
+	// INFO 1
+}
+
+func ExampleCountBy() {
+	fmt.Println(xiter.CountBy(func(v int) bool { return v%2 == 0 }, slices.Values([]int{1, 2, 3, 4, 5})))
+
+	// Output:
+	// map[false:3 true:2]
+}
+
+func ExamplePartition() {
+	even, odd := xiter.Partition(func(v int) bool { return v%2 == 0 }, slices.Values([]int{1, 2, 3, 4, 5}))
+
+	fmt.Println(even, odd)
+
+	// Output:
+	// [2 4] [1 3 5]
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, xiter.GroupBy(func(v int) int { return v }, xiter.Empty[int]))
+	assert.Empty(t, xiter.CountBy(func(v int) int { return v }, xiter.Empty[int]))
+
+	yes, no := xiter.Partition(func(int) bool { return true }, xiter.Empty[int])
+	assert.Nil(t, yes)
+	assert.Nil(t, no)
+}
+
+func TestGroupByConsecutive(t *testing.T) {
+	t.Parallel()
+
+	type group struct {
+		key    int
+		values []int
+	}
+
+	var groups []group
+
+	for k, v := range xiter.GroupByConsecutive(func(v int) int { return v / 10 }, slices.Values([]int{1, 2, 11, 3, 4})) {
+		groups = append(groups, group{k, v})
+	}
+
+	assert.Equal(t, []group{{0, []int{1, 2}}, {1, []int{11}}, {0, []int{3, 4}}}, groups)
+
+	for range xiter.GroupByConsecutive(func(v int) int { return v }, xiter.Empty[int]) {
+		t.Fatal("unexpected group")
+	}
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for k, v := range xiter.GroupByConsecutive(func(v int) int { return v / 3 }, trackedSeq(100, &pulled, &finished)) {
+		assert.Equal(t, 0, k)
+		assert.Equal(t, []int{0, 1, 2}, v)
+
+		break
+	}
+
+	assert.Equal(t, 4, pulled)
+	assert.True(t, finished)
+}
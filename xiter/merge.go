@@ -0,0 +1,214 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// MergeSorted returns an iterator merging the sequences, each sorted by cmp, into a single sorted sequence.
+//
+// Equal elements are yielded in the order of the sequences they come from. Only one element of each sequence
+// is held in memory at a time.
+func MergeSorted[V any](cmp func(a, b V) int, seqs ...iter.Seq[V]) iter.Seq[V] {
+	seqs2 := make([]iter.Seq2[V, struct{}], 0, len(seqs))
+
+	for _, seq := range seqs {
+		seqs2 = append(seqs2, ToSeq2(func(v V) (V, struct{}) { return v, struct{}{} }, seq))
+	}
+
+	return Keys(MergeSortedFunc2(cmp, seqs2...))
+}
+
+// MergeSortedFunc2 returns an iterator merging the sequences, each sorted by key with cmp, into a single sequence
+// sorted by key.
+//
+// Equal keys are yielded in the order of the sequences they come from. Only one element of each sequence
+// is held in memory at a time.
+func MergeSortedFunc2[K, V any](cmp func(a, b K) int, seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h := &mergeHeap[K, V]{cmp: cmp}
+
+		stops := make([]func(), 0, len(seqs))
+
+		defer func() {
+			for _, stop := range stops {
+				stop()
+			}
+		}()
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull2(seq)
+			stops = append(stops, stop)
+
+			src := &mergeSource[K, V]{next: next, index: i}
+			if src.pull() {
+				h.sources = append(h.sources, src)
+			}
+		}
+
+		heap.Init(h)
+
+		for h.Len() > 0 {
+			src := h.sources[0]
+
+			if !yield(src.key, src.val) {
+				return
+			}
+
+			if src.pull() {
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+type mergeSource[K, V any] struct {
+	key   K
+	val   V
+	next  func() (K, V, bool)
+	index int
+}
+
+func (src *mergeSource[K, V]) pull() bool {
+	var ok bool
+
+	src.key, src.val, ok = src.next()
+
+	return ok
+}
+
+// mergeHeap implements heap.Interface ordering sources by their current key, and by sequence index for equal keys.
+type mergeHeap[K, V any] struct {
+	cmp     func(a, b K) int
+	sources []*mergeSource[K, V]
+}
+
+func (h *mergeHeap[K, V]) Len() int { return len(h.sources) }
+
+func (h *mergeHeap[K, V]) Less(i, j int) bool {
+	if c := h.cmp(h.sources[i].key, h.sources[j].key); c != 0 {
+		return c < 0
+	}
+
+	return h.sources[i].index < h.sources[j].index
+}
+
+func (h *mergeHeap[K, V]) Swap(i, j int) { h.sources[i], h.sources[j] = h.sources[j], h.sources[i] }
+
+func (h *mergeHeap[K, V]) Push(x any) { h.sources = append(h.sources, x.(*mergeSource[K, V])) } //nolint:errcheck,forcetypeassert
+
+func (h *mergeHeap[K, V]) Pop() any {
+	n := len(h.sources) - 1
+	src := h.sources[n]
+	h.sources[n] = nil
+	h.sources = h.sources[:n]
+
+	return src
+}
+
+// UnionSorted returns an iterator over the elements of a and b, which must be sorted by cmp, in sorted order.
+// Elements present in both sequences are yielded once, taken from a.
+func UnionSorted[V any](cmp func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		va, okA := nextA()
+		vb, okB := nextB()
+
+		for okA && okB {
+			switch c := cmp(va, vb); {
+			case c < 0:
+				if !yield(va) {
+					return
+				}
+
+				va, okA = nextA()
+			case c > 0:
+				if !yield(vb) {
+					return
+				}
+
+				vb, okB = nextB()
+			default:
+				if !yield(va) {
+					return
+				}
+
+				va, okA = nextA()
+				vb, okB = nextB()
+			}
+		}
+
+		for ; okA; va, okA = nextA() {
+			if !yield(va) {
+				return
+			}
+		}
+
+		for ; okB; vb, okB = nextB() {
+			if !yield(vb) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectSorted returns an iterator over the elements of a which are also present in b.
+// Both sequences must be sorted by cmp.
+func IntersectSorted[V any](cmp func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		vb, okB := nextB()
+
+		for va := range a {
+			for okB && cmp(vb, va) < 0 {
+				vb, okB = nextB()
+			}
+
+			if !okB {
+				return
+			}
+
+			if cmp(va, vb) == 0 && !yield(va) {
+				return
+			}
+		}
+	}
+}
+
+// DifferenceSorted returns an iterator over the elements of a which are not present in b.
+// Both sequences must be sorted by cmp.
+func DifferenceSorted[V any](cmp func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		vb, okB := nextB()
+
+		for va := range a {
+			for okB && cmp(vb, va) < 0 {
+				vb, okB = nextB()
+			}
+
+			if okB && cmp(va, vb) == 0 {
+				continue
+			}
+
+			if !yield(va) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleMergeSorted() {
+	shard1 := slices.Values([]int{1, 4, 7})
+	shard2 := slices.Values([]int{2, 5, 8})
+	shard3 := slices.Values([]int{3, 6, 9})
+
+	fmt.Println(slices.Collect(xiter.MergeSorted(cmp.Compare[int], shard1, shard2, shard3)))
+
+	// Output:
+	// [1 2 3 4 5 6 7 8 9]
+}
+
+func ExampleMergeSortedFunc2() {
+	node1 := slices.All([]string{"boot", "network", "kubelet"})
+	node2 := slices.All([]string{"boot", "apid"})
+
+	for line, msg := range xiter.MergeSortedFunc2(cmp.Compare[int], node1, node2) {
+		fmt.Println(line, msg)
+	}
+
+	// Output:
+	// 0 boot
+	// 0 boot
+	// 1 network
+	// 1 apid
+	// 2 kubelet
+}
+
+func ExampleUnionSorted() {
+	a := slices.Values([]int{1, 3, 5})
+	b := slices.Values([]int{3, 4, 5, 6})
+
+	fmt.Println(slices.Collect(xiter.UnionSorted(cmp.Compare[int], a, b)))
+	fmt.Println(slices.Collect(xiter.IntersectSorted(cmp.Compare[int], a, b)))
+	fmt.Println(slices.Collect(xiter.DifferenceSorted(cmp.Compare[int], a, b)))
+
+	// Output:
+	// [1 3 4 5 6]
+	// [3 5]
+	// [1]
+}
+
+func TestMergeSorted(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	var (
+		seqs     []iter.Seq[int]
+		expected []int
+	)
+
+	for range 10 {
+		s := make([]int, rnd.IntN(20))
+
+		for i := range s {
+			s[i] = rnd.IntN(50)
+		}
+
+		slices.Sort(s)
+
+		seqs = append(seqs, slices.Values(s))
+		expected = append(expected, s...)
+	}
+
+	slices.Sort(expected)
+
+	assert.Equal(t, expected, slices.Collect(xiter.MergeSorted(cmp.Compare[int], seqs...)))
+	assert.Empty(t, slices.Collect(xiter.MergeSorted[int](cmp.Compare[int])))
+	assert.Empty(t, slices.Collect(xiter.MergeSorted(cmp.Compare[int], xiter.Empty[int], xiter.Empty[int])))
+
+	// stable: equal keys come in the order of sequences
+	merged := slices.Collect(xiter.ToSeq(func(k int, v string) string { return fmt.Sprint(k, v) },
+		xiter.MergeSortedFunc2(cmp.Compare[int],
+			xiter.Single2(1, "a"),
+			xiter.Concat2(xiter.Single2(0, "b"), xiter.Single2(1, "b")),
+			xiter.Single2(1, "c"),
+		),
+	))
+	assert.Equal(t, []string{"0b", "1a", "1b", "1c"}, merged)
+}
+
+func TestMergeSortedStop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulledA, pulledB     int
+		finishedA, finishedB bool
+	)
+
+	for v := range xiter.MergeSorted(cmp.Compare[int], trackedSeq(100, &pulledA, &finishedA), trackedSeq(100, &pulledB, &finishedB)) {
+		if v == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 3, pulledA)
+	assert.Equal(t, 3, pulledB)
+	assert.True(t, finishedA)
+	assert.True(t, finishedB)
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	t.Parallel()
+
+	evens := slices.Values([]int{0, 2, 4, 6, 8})
+	threes := slices.Values([]int{0, 3, 6, 9})
+
+	assert.Equal(t, []int{0, 2, 3, 4, 6, 8, 9}, slices.Collect(xiter.UnionSorted(cmp.Compare[int], evens, threes)))
+	assert.Equal(t, []int{0, 6}, slices.Collect(xiter.IntersectSorted(cmp.Compare[int], evens, threes)))
+	assert.Equal(t, []int{2, 4, 8}, slices.Collect(xiter.DifferenceSorted(cmp.Compare[int], evens, threes)))
+	assert.Equal(t, []int{3, 9}, slices.Collect(xiter.DifferenceSorted(cmp.Compare[int], threes, evens)))
+
+	assert.Equal(t, []int{0, 2}, slices.Collect(xiter.UnionSorted(cmp.Compare[int], xiter.Empty[int], slices.Values([]int{0, 2}))))
+	assert.Empty(t, slices.Collect(xiter.IntersectSorted(cmp.Compare[int], evens, xiter.Empty[int])))
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, slices.Collect(xiter.DifferenceSorted(cmp.Compare[int], evens, xiter.Empty[int])))
+
+	var (
+		pulledA, pulledB     int
+		finishedA, finishedB bool
+	)
+
+	// b is exhausted, so intersection ends without pulling the rest of a
+	assert.Equal(t, []int{1}, slices.Collect(xiter.IntersectSorted(cmp.Compare[int],
+		trackedSeq(100, &pulledA, &finishedA), slices.Values([]int{1}))))
+	assert.Equal(t, 3, pulledA)
+	assert.True(t, finishedA)
+
+	pulledA, finishedA = 0, false
+
+	for range xiter.UnionSorted(cmp.Compare[int], trackedSeq(100, &pulledA, &finishedA), trackedSeq(100, &pulledB, &finishedB)) {
+		break
+	}
+
+	assert.Equal(t, 1, pulledA)
+	assert.Equal(t, 1, pulledB)
+	assert.True(t, finishedA)
+	assert.True(t, finishedB)
+}
@@ -0,0 +1,221 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/siderolabs/gen/panicsafe"
+)
+
+type parallelResult[V any] struct {
+	val V
+	err error
+}
+
+type parallelTask[In, Out any] struct {
+	in  In
+	res chan<- parallelResult[Out]
+}
+
+// ParallelMap returns an iterator over f applied to seq by a pool of workers, yielding results in input order.
+//
+// At most 2*workers elements are processed or waiting to be yielded at any time, so a slow element
+// doesn't make the results of the following ones pile up. Errors returned by f and panics in f or seq
+// (converted to errors by panicsafe) are yielded with a zero value, and iteration continues.
+// If ctx is canceled, ctx.Err() is yielded as the last element.
+//
+// Seq is iterated in a separate goroutine. When the consumer stops, the context passed to f is canceled,
+// and the iterator returns once all workers have finished, so f should honor the context.
+// It panics if workers is not positive.
+func ParallelMap[In, Out any](ctx context.Context, workers int, f func(context.Context, In) (Out, error), seq iter.Seq[In]) iter.Seq2[Out, error] {
+	if workers <= 0 {
+		panic(fmt.Sprintf("number of workers must be positive, got %d", workers))
+	}
+
+	return func(yield func(Out, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		var wg sync.WaitGroup
+
+		defer wg.Wait()
+		defer cancel()
+
+		tasks := make(chan parallelTask[In, Out])
+		// pending holds per-element result channels in input order, its capacity bounds the reorder buffer;
+		// the consumer holds one more channel taken out of it while waiting for the result
+		pending := make(chan chan parallelResult[Out], 2*workers-1)
+
+		startParallelWorkers(ctx, &wg, workers, f, tasks)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer close(pending)
+
+			produceParallelTasks(ctx, seq, tasks, func() chan parallelResult[Out] {
+				res := make(chan parallelResult[Out], 1)
+
+				select {
+				case pending <- res:
+					return res
+				case <-ctx.Done():
+					return nil
+				}
+			})
+		}()
+
+		for res := range pending {
+			select {
+			case r := <-res:
+				if !yield(r.val, r.err) {
+					return
+				}
+			case <-ctx.Done():
+				yield(*new(Out), ctx.Err())
+
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(*new(Out), err)
+		}
+	}
+}
+
+// ParallelMapUnordered is like ParallelMap, but yields results as soon as they are ready, in no particular order.
+//
+// It panics if workers is not positive.
+func ParallelMapUnordered[In, Out any](ctx context.Context, workers int, f func(context.Context, In) (Out, error), seq iter.Seq[In]) iter.Seq2[Out, error] {
+	if workers <= 0 {
+		panic(fmt.Sprintf("number of workers must be positive, got %d", workers))
+	}
+
+	return func(yield func(Out, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		var wg sync.WaitGroup
+
+		tasks := make(chan parallelTask[In, Out])
+		results := make(chan parallelResult[Out])
+
+		defer func() {
+			cancel()
+
+			// wait for all senders to finish
+			for range results { //nolint:revive
+			}
+		}()
+
+		startParallelWorkers(ctx, &wg, workers, f, tasks)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			produceParallelTasks(ctx, seq, tasks, func() chan parallelResult[Out] { return results })
+		}()
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for r := range results {
+			if !yield(r.val, r.err) {
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(*new(Out), err)
+		}
+	}
+}
+
+func startParallelWorkers[In, Out any](
+	ctx context.Context, wg *sync.WaitGroup, workers int, f func(context.Context, In) (Out, error), tasks <-chan parallelTask[In, Out],
+) {
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for task := range tasks {
+				if ctx.Err() != nil {
+					return
+				}
+
+				var r parallelResult[Out]
+
+				r.err = panicsafe.RunErr(func() error {
+					val, err := f(ctx, task.in)
+					if err == nil {
+						r.val = val
+					}
+
+					return err
+				})
+
+				select {
+				case task.res <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// produceParallelTasks feeds the elements of seq to the workers, using next to allocate the result channel for each.
+//
+// It closes tasks when done, and reports a panic in seq as the last result.
+func produceParallelTasks[In, Out any](
+	ctx context.Context, seq iter.Seq[In], tasks chan<- parallelTask[In, Out], next func() chan parallelResult[Out],
+) {
+	defer close(tasks)
+
+	err := panicsafe.Run(func() {
+		for in := range seq {
+			// select picks randomly among ready cases, so check for cancellation explicitly
+			// to stop pulling from seq promptly
+			if ctx.Err() != nil {
+				return
+			}
+
+			res := next()
+			if res == nil {
+				return
+			}
+
+			select {
+			case tasks <- parallelTask[In, Out]{in: in, res: res}:
+			case <-ctx.Done():
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	})
+	if err == nil {
+		return
+	}
+
+	if res := next(); res != nil {
+		select {
+		case res <- parallelResult[Out]{err: err}:
+		case <-ctx.Done():
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/panicsafe"
+	"github.com/siderolabs/gen/xiter"
+)
+
+type parallelMapFunc = func(context.Context, int, func(context.Context, int) (int, error), iter.Seq[int]) iter.Seq2[int, error]
+
+func ExampleParallelMap() {
+	square := func(_ context.Context, v int) (int, error) { return v * v, nil }
+
+	for v, err := range xiter.ParallelMap(context.Background(), 4, square, slices.Values([]int{1, 2, 3, 4, 5})) {
+		fmt.Println(v, err)
+	}
+
+	// Output:
+	// 1 <nil>
+	// 4 <nil>
+	// 9 <nil>
+	// 16 <nil>
+	// 25 <nil>
+}
+
+func TestParallelMap(t *testing.T) {
+	t.Parallel()
+
+	// later elements finish first, but results are still in input order
+	sleepy := func(_ context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(10-v) * time.Millisecond)
+
+		return v * 10, nil
+	}
+
+	values, err := xiter.CollectErr(xiter.ParallelMap(t.Context(), 3, sleepy, slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, values)
+
+	values, err = xiter.CollectErr(xiter.ParallelMapUnordered(t.Context(), 3, sleepy, slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, values)
+
+	assert.Panics(t, func() { xiter.ParallelMap(t.Context(), 0, sleepy, xiter.Empty[int]) })
+	assert.Panics(t, func() { xiter.ParallelMapUnordered(t.Context(), -1, sleepy, xiter.Empty[int]) })
+}
+
+func TestParallelMapErrors(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+
+	f := func(_ context.Context, v int) (int, error) {
+		switch {
+		case v == 3:
+			panic("three")
+		case v%2 == 1:
+			return v, errOdd
+		default:
+			return v, nil
+		}
+	}
+
+	var results []string
+
+	for v, err := range xiter.ParallelMap(t.Context(), 2, f, slices.Values([]int{0, 1, 2, 3, 4})) {
+		switch {
+		case panicsafe.IsPanic(err):
+			results = append(results, "panic")
+		case err != nil:
+			require.ErrorIs(t, err, errOdd)
+			require.Zero(t, v)
+
+			results = append(results, "error")
+		default:
+			results = append(results, fmt.Sprint(v))
+		}
+	}
+
+	assert.Equal(t, []string{"0", "error", "2", "panic", "4"}, results)
+
+	panicking := func(yield func(int) bool) {
+		yield(1)
+
+		panic("source")
+	}
+
+	for _, parallelMap := range []parallelMapFunc{
+		xiter.ParallelMap[int, int],
+		xiter.ParallelMapUnordered[int, int],
+	} {
+		var errs []error
+
+		for _, err := range parallelMap(t.Context(), 2, f, panicking) {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 2)
+		assert.True(t, panicsafe.IsPanic(errors.Join(errs...)))
+	}
+}
+
+func TestParallelMapStop(t *testing.T) {
+	t.Parallel()
+
+	for _, parallelMap := range []parallelMapFunc{
+		xiter.ParallelMap[int, int],
+		xiter.ParallelMapUnordered[int, int],
+	} {
+		var (
+			pulled   int
+			finished bool
+			running  atomic.Int32
+		)
+
+		f := func(ctx context.Context, v int) (int, error) {
+			running.Add(1)
+			defer running.Add(-1)
+
+			if v > 0 {
+				// block until canceled
+				<-ctx.Done()
+			}
+
+			return v, ctx.Err()
+		}
+
+		for v, err := range parallelMap(t.Context(), 4, f, trackedSeq(1000, &pulled, &finished)) {
+			require.NoError(t, err)
+			require.Zero(t, v)
+
+			break
+		}
+
+		assert.Zero(t, running.Load(), "all workers must be finished")
+		assert.True(t, finished)
+		assert.LessOrEqual(t, pulled, 4*3, "elements pulled must be bounded")
+	}
+}
+
+func TestParallelMapReorderBuffer(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	// the first element is slow, so the following results have to wait in the reorder buffer
+	f := func(_ context.Context, v int) (int, error) {
+		calls.Add(1)
+
+		if v == 0 {
+			<-release
+		}
+
+		return v, nil
+	}
+
+	go func() {
+		defer close(release)
+
+		assert.Eventually(t, func() bool { return calls.Load() == 4 }, time.Second, time.Millisecond)
+
+		// no more than 2*workers elements are in flight
+		time.Sleep(50 * time.Millisecond)
+		assert.EqualValues(t, 4, calls.Load())
+	}()
+
+	values, err := xiter.CollectErr(xiter.ParallelMap(t.Context(), 2, f, slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, values)
+}
+
+func TestParallelMapCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	f := func(ctx context.Context, v int) (int, error) {
+		if v == 2 {
+			cancel()
+		}
+
+		if v > 1 {
+			<-ctx.Done()
+
+			return 0, ctx.Err()
+		}
+
+		return v, nil
+	}
+
+	values, err := xiter.CollectErr(xiter.ParallelMap(ctx, 1, f, slices.Values([]int{0, 1, 2, 3, 4})))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Subset(t, []int{0, 1}, values)
+}
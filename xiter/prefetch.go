@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/siderolabs/gen/channel"
+	"github.com/siderolabs/gen/panicsafe"
+)
+
+// Prefetch returns an iterator over seq which runs seq on a separate goroutine up to n elements ahead of the consumer.
+//
+// All elements are yielded with a nil error; a panic in seq is converted to an error by panicsafe and yielded
+// as the last element. When the consumer stops, the producer is stopped as well, and the iterator returns once
+// it has finished.
+// It panics if n is not positive.
+func Prefetch[V any](n int, seq iter.Seq[V]) iter.Seq2[V, error] {
+	if n <= 0 {
+		panic(fmt.Sprintf("prefetch buffer size must be positive, got %d", n))
+	}
+
+	return func(yield func(V, error) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan parallelResult[V], n)
+
+		defer func() {
+			cancel()
+
+			// wait for the producer to finish
+			for range ch { //nolint:revive
+			}
+		}()
+
+		go func() {
+			defer close(ch)
+
+			err := panicsafe.Run(func() {
+				for v := range seq {
+					if !channel.SendWithContext(ctx, ch, parallelResult[V]{val: v}) {
+						return
+					}
+
+					// the drain on exit frees buffer space, so the send might win over cancellation:
+					// check it explicitly to stop pulling from seq
+					if ctx.Err() != nil {
+						return
+					}
+				}
+			})
+			if err != nil {
+				channel.SendWithContext(ctx, ch, parallelResult[V]{err: err})
+			}
+		}()
+
+		for r := range ch {
+			if !yield(r.val, r.err) {
+				return
+			}
+		}
+	}
+}
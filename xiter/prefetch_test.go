@@ -0,0 +1,147 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/gen/panicsafe"
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExamplePrefetch() {
+	for v, err := range xiter.Prefetch(2, slices.Values([]int{1, 2, 3})) {
+		fmt.Println(v, err)
+	}
+
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// 3 <nil>
+}
+
+func TestPrefetch(t *testing.T) {
+	t.Parallel()
+
+	values, err := xiter.CollectErr(xiter.Prefetch(1, slices.Values([]int{1, 2, 3})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	values, err = xiter.CollectErr(xiter.Prefetch(3, xiter.Empty[int]))
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	assert.Panics(t, func() { xiter.Prefetch(0, xiter.Empty[int]) })
+}
+
+func TestPrefetchAhead(t *testing.T) {
+	t.Parallel()
+
+	pulled := make(chan int, 100)
+	source := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled <- i
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	for v := range xiter.Prefetch(3, source) {
+		assert.Zero(t, v)
+
+		// the producer fills the buffer while the consumer is busy
+		require.Eventually(t, func() bool { return len(pulled) >= 4 }, time.Second, time.Millisecond)
+
+		break
+	}
+
+	// once stopped, the producer is finished and doesn't pull anymore
+	assert.LessOrEqual(t, len(pulled), 5)
+}
+
+func TestPrefetchStop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for range xiter.Prefetch(2, trackedSeq(1000, &pulled, &finished)) {
+		break
+	}
+
+	// the iterator waits for the producer, so reading the counters is race-free
+	assert.True(t, finished)
+	assert.LessOrEqual(t, pulled, 4)
+
+	// slow source: the producer must not keep pulling while the buffer is drained on exit
+	slow := func(pulled *int) func(yield func(int) bool) {
+		return func(yield func(int) bool) {
+			for i := range 1000 {
+				time.Sleep(100 * time.Microsecond)
+
+				*pulled++
+
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	for range 100 {
+		pulled = 0
+
+		for range xiter.Prefetch(1, slow(&pulled)) {
+			time.Sleep(time.Millisecond)
+
+			break
+		}
+
+		// one consumed, one buffered and one blocked on send
+		require.LessOrEqual(t, pulled, 3)
+	}
+}
+
+func TestPrefetchPanic(t *testing.T) {
+	t.Parallel()
+
+	source := func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+
+		panic("disk on fire")
+	}
+
+	var (
+		values []int
+		errs   []error
+	)
+
+	for v, err := range xiter.Prefetch(2, source) {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		values = append(values, v)
+	}
+
+	assert.Equal(t, []int{1}, values)
+	require.Len(t, errs, 1)
+	assert.True(t, panicsafe.IsPanic(errs[0]))
+	assert.ErrorContains(t, errs[0], "disk on fire")
+}
@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import "iter"
+
+// Take returns an iterator over the first n elements of seq.
+// It stops pulling from seq right after the n-th element; if n is not positive, seq is not pulled at all.
+func Take[V any](n int, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+
+		for v := range seq {
+			i++
+
+			if !yield(v) || i == n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns an iterator over the first n elements of seq.
+// It stops pulling from seq right after the n-th element; if n is not positive, seq is not pulled at all.
+func Take2[K, V any](n int, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+
+		for k, v := range seq {
+			i++
+
+			if !yield(k, v) || i == n {
+				return
+			}
+		}
+	}
+}
+
+// Limit is an alias for Take.
+func Limit[V any](n int, seq iter.Seq[V]) iter.Seq[V] {
+	return Take(n, seq)
+}
+
+// Limit2 is an alias for Take2.
+func Limit2[K, V any](n int, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Take2(n, seq)
+}
+
+// Skip returns an iterator over the elements of seq except the first n.
+func Skip[V any](n int, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		i := 0
+
+		for v := range seq {
+			if i < n {
+				i++
+
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 returns an iterator over the elements of seq except the first n.
+func Skip2[K, V any](n int, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		i := 0
+
+		for k, v := range seq {
+			if i < n {
+				i++
+
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns an iterator over the leading elements of seq for which f returns true.
+// It stops pulling from seq at the first element for which f returns false.
+func TakeWhile[V any](f func(V) bool, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if !f(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile2 returns an iterator over the leading elements of seq for which f returns true.
+// It stops pulling from seq at the first element for which f returns false.
+func TakeWhile2[K, V any](f func(K, V) bool, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if !f(k, v) || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile returns an iterator over the elements of seq starting with the first one for which f returns false.
+// Once an element is yielded, f is not called anymore.
+func DropWhile[V any](f func(V) bool, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		dropping := true
+
+		for v := range seq {
+			if dropping && f(v) {
+				continue
+			}
+
+			dropping = false
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile2 returns an iterator over the elements of seq starting with the first one for which f returns false.
+// Once an element is yielded, f is not called anymore.
+func DropWhile2[K, V any](f func(K, V) bool, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		dropping := true
+
+		for k, v := range seq {
+			if dropping && f(k, v) {
+				continue
+			}
+
+			dropping = false
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
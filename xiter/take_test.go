@@ -0,0 +1,172 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleTake() {
+	// page 2 with 3 items per page
+	for v := range xiter.Take(3, xiter.Skip(3, slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8}))) {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 4
+	// 5
+	// 6
+}
+
+func ExampleTake2() {
+	for i, v := range xiter.Take2(2, slices.All([]string{"a", "b", "c"})) {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 0 a
+	// 1 b
+}
+
+func ExampleSkip2() {
+	for i, v := range xiter.Skip2(2, slices.All([]string{"a", "b", "c"})) {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 2 c
+}
+
+func ExampleTakeWhile() {
+	for v := range xiter.TakeWhile(func(v int) bool { return v < 3 }, slices.Values([]int{1, 2, 3, 1})) {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleDropWhile() {
+	for v := range xiter.DropWhile(func(v int) bool { return v < 3 }, slices.Values([]int{1, 2, 3, 1})) {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 3
+	// 1
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(xiter.Take(3, trackedSeq(100, &pulled, &finished))))
+	assert.Equal(t, 3, pulled, "no element must be pulled after the last one")
+	assert.True(t, finished)
+
+	pulled = 0
+
+	assert.Empty(t, slices.Collect(xiter.Take(0, trackedSeq(100, &pulled, &finished))))
+	assert.Empty(t, slices.Collect(xiter.Take(-1, trackedSeq(100, &pulled, &finished))))
+	assert.Zero(t, pulled)
+
+	assert.Equal(t, []int{0, 1}, slices.Collect(xiter.Take(5, slices.Values([]int{0, 1}))))
+
+	for range xiter.Take(10, trackedSeq(100, &pulled, &finished)) {
+		if pulled == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, pulled)
+
+	m := maps.Collect(xiter.Take2(2, xiter.Enumerate(trackedSeq(100, &pulled, &finished))))
+	assert.Equal(t, map[int]int{0: 0, 1: 1}, m)
+	assert.Equal(t, 4, pulled)
+	assert.Empty(t, maps.Collect(xiter.Take2(0, slices.All([]int{1}))))
+
+	assert.Equal(t, []int{0, 1}, slices.Collect(xiter.Limit(2, slices.Values([]int{0, 1, 2}))))
+	assert.Equal(t, map[int]int{0: 0}, maps.Collect(xiter.Limit2(1, slices.All([]int{0, 1}))))
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []int{2, 3}, slices.Collect(xiter.Skip(2, slices.Values([]int{0, 1, 2, 3}))))
+	assert.Equal(t, []int{0, 1}, slices.Collect(xiter.Skip(-1, slices.Values([]int{0, 1}))))
+	assert.Empty(t, slices.Collect(xiter.Skip(5, slices.Values([]int{0, 1}))))
+	assert.Equal(t, map[int]int{1: 1}, maps.Collect(xiter.Skip2(1, slices.All([]int{0, 1}))))
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for range xiter.Skip(3, trackedSeq(100, &pulled, &finished)) {
+		break
+	}
+
+	assert.Equal(t, 4, pulled)
+	assert.True(t, finished)
+}
+
+func TestTakeWhile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(xiter.TakeWhile(func(v int) bool { return v < 3 }, trackedSeq(100, &pulled, &finished))))
+	assert.Equal(t, 4, pulled, "only the first rejected element must be pulled")
+	assert.True(t, finished)
+
+	pulled = 0
+
+	m := maps.Collect(xiter.TakeWhile2(func(k, _ int) bool { return k < 2 }, xiter.Enumerate(trackedSeq(100, &pulled, &finished))))
+	assert.Equal(t, map[int]int{0: 0, 1: 1}, m)
+	assert.Equal(t, 3, pulled)
+}
+
+func TestDropWhile(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	lessThan2 := func(v int) bool {
+		calls++
+
+		return v < 2
+	}
+
+	assert.Equal(t, []int{2, 3, 0}, slices.Collect(xiter.DropWhile(lessThan2, slices.Values([]int{0, 1, 2, 3, 0}))))
+	assert.Equal(t, 3, calls, "predicate must not be called after the first yielded element")
+
+	assert.Equal(t, map[int]int{2: 2}, maps.Collect(xiter.DropWhile2(func(k, _ int) bool { return k < 2 }, slices.All([]int{0, 1, 2}))))
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for range xiter.DropWhile(func(v int) bool { return v < 5 }, trackedSeq(100, &pulled, &finished)) {
+		break
+	}
+
+	assert.Equal(t, 6, pulled)
+	assert.True(t, finished)
+}
@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter
+
+import (
+	"iter"
+
+	"github.com/siderolabs/gen/optional"
+)
+
+// Zip returns an iterator over pairs of elements from a and b, walked in lockstep.
+// The iteration stops when either sequence ends.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest returns an iterator over pairs of elements from a and b, walked in lockstep.
+// The iteration continues until both sequences end, yielding empty optionals for the side which ended first.
+func ZipLongest[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[optional.Optional[A], optional.Optional[B]] {
+	return func(yield func(optional.Optional[A], optional.Optional[B]) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			optB := optional.None[B]()
+			if vb, ok := next(); ok {
+				optB = optional.Some(vb)
+			}
+
+			if !yield(optional.Some(va), optB) {
+				return
+			}
+		}
+
+		for {
+			vb, ok := next()
+			if !ok || !yield(optional.None[A](), optional.Some(vb)) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip collects the pairs from seq into two slices.
+func Unzip[K, V any](seq iter.Seq2[K, V]) ([]K, []V) {
+	var (
+		keys   []K
+		values []V
+	)
+
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	return keys, values
+}
+
+// Enumerate returns an iterator over the elements of seq paired with their index, starting from zero.
+func Enumerate[V any](seq iter.Seq[V]) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		i := 0
+
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+
+			i++
+		}
+	}
+}
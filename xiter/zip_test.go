@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package xiter_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/siderolabs/gen/xiter"
+)
+
+func ExampleZip() {
+	names := slices.Values([]string{"alpha", "beta", "gamma"})
+	ports := slices.Values([]int{80, 443})
+
+	for name, port := range xiter.Zip(names, ports) {
+		fmt.Println(name, port)
+	}
+
+	// Output:
+	// alpha 80
+	// beta 443
+}
+
+func ExampleZipLongest() {
+	names := slices.Values([]string{"alpha", "beta", "gamma"})
+	ports := slices.Values([]int{80, 443})
+
+	for name, port := range xiter.ZipLongest(names, ports) {
+		fmt.Println(name.ValueOr("-"), port.ValueOr(-1))
+	}
+
+	// Output:
+	// alpha 80
+	// beta 443
+	// gamma -1
+}
+
+func ExampleUnzip() {
+	keys, values := xiter.Unzip(xiter.Enumerate(slices.Values([]string{"a", "b", "c"})))
+
+	fmt.Println(keys, values)
+
+	// Output:
+	// [0 1 2] [a b c]
+}
+
+func ExampleEnumerate() {
+	for i, v := range xiter.Enumerate(slices.Values([]string{"a", "b", "c"})) {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 0 a
+	// 1 b
+	// 2 c
+}
+
+// trackedSeq returns a sequence of integers [0, n) which records how many elements were pulled,
+// and whether it was finished (either exhausted or stopped).
+func trackedSeq(n int, pulled *int, finished *bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		defer func() { *finished = true }()
+
+		for i := range n {
+			*pulled++
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestZipStop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulledA, pulledB     int
+		finishedA, finishedB bool
+	)
+
+	for range xiter.Zip(trackedSeq(10, &pulledA, &finishedA), trackedSeq(10, &pulledB, &finishedB)) {
+		if pulledA == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, 3, pulledA)
+	assert.Equal(t, 3, pulledB)
+	assert.True(t, finishedA)
+	assert.True(t, finishedB, "pulled sequence must be stopped")
+
+	pulledA, pulledB, finishedA, finishedB = 0, 0, false, false
+
+	for range xiter.ZipLongest(trackedSeq(2, &pulledA, &finishedA), trackedSeq(10, &pulledB, &finishedB)) {
+		if pulledB == 5 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, pulledA)
+	assert.Equal(t, 5, pulledB)
+	assert.True(t, finishedA)
+	assert.True(t, finishedB, "pulled sequence must be stopped")
+}
+
+func TestEnumerateStop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pulled   int
+		finished bool
+	)
+
+	for i := range xiter.Enumerate(trackedSeq(10, &pulled, &finished)) {
+		if i == 4 {
+			break
+		}
+	}
+
+	assert.Equal(t, 5, pulled)
+	assert.True(t, finished)
+}